@@ -0,0 +1,100 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package altsrc
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// EncryptedValuePrefix marks an input source value as encrypted, e.g.
+// `password: enc:c2VjcmV0...`. A plain value starting with the prefix is
+// written with the prefix doubled up to the colon, `enc::value` standing for
+// `enc:value`.
+const EncryptedValuePrefix = "enc:"
+
+// escapedValuePrefix starts the plain values beginning with
+// EncryptedValuePrefix
+const escapedValuePrefix = EncryptedValuePrefix + ":"
+
+// Decrypter decrypts the input source values marked with
+// EncryptedValuePrefix. Decrypt receives the value without the prefix.
+type Decrypter interface {
+	Decrypt(ciphertext string) (string, error)
+}
+
+// DecrypterFunc is an adapter to allow the use of ordinary functions as
+// Decrypters.
+type DecrypterFunc func(ciphertext string) (string, error)
+
+// Decrypt calls f(ciphertext).
+func (f DecrypterFunc) Decrypt(ciphertext string) (string, error) {
+	return f(ciphertext)
+}
+
+var (
+	decrypterMu sync.RWMutex
+	decrypter   Decrypter
+)
+
+// SetDecrypter sets the Decrypter used when string values are applied to
+// flags. Without a Decrypter, encrypted values are rejected rather than
+// being applied as they are.
+func SetDecrypter(d Decrypter) {
+	decrypterMu.Lock()
+	defer decrypterMu.Unlock()
+
+	decrypter = d
+}
+
+// decryptValue returns value decrypted when it is marked as encrypted,
+// unescaped when it starts with escapedValuePrefix and unchanged otherwise.
+// Errors never include the value itself.
+func decryptValue(key, value string) (string, error) {
+	if strings.HasPrefix(value, escapedValuePrefix) {
+		return EncryptedValuePrefix + strings.TrimPrefix(value, escapedValuePrefix), nil
+	}
+	if !strings.HasPrefix(value, EncryptedValuePrefix) {
+		return value, nil
+	}
+
+	decrypterMu.RLock()
+	d := decrypter
+	decrypterMu.RUnlock()
+
+	if d == nil {
+		return "", fmt.Errorf("Unable to decrypt '%s': no Decrypter configured", key)
+	}
+
+	plaintext, err := d.Decrypt(strings.TrimPrefix(value, EncryptedValuePrefix))
+	if err != nil {
+		return "", fmt.Errorf("Unable to decrypt '%s': %v", key, err)
+	}
+	return plaintext, nil
+}
+
+// decryptValues returns the values of the slice at key decrypted like
+// decryptValue
+func decryptValues(key string, values []string) ([]string, error) {
+	decrypted := make([]string, len(values))
+	for i, v := range values {
+		var err error
+		if decrypted[i], err = decryptValue(fmt.Sprintf("%s[%d]", key, i), v); err != nil {
+			return nil, err
+		}
+	}
+	return decrypted, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package altsrc
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"syscall"
+)
+
+// AESGCMDecrypter decrypts values sealed with AES-GCM. An encrypted value is
+// the standard base64 encoding of the nonce followed by the sealed data, as
+// produced by Encrypt.
+type AESGCMDecrypter struct {
+	aead cipher.AEAD
+}
+
+// NewAESGCMDecrypter creates an AESGCMDecrypter from a raw 16, 24 or 32 byte
+// key, selecting AES-128, AES-192 or AES-256.
+func NewAESGCMDecrypter(key []byte) (*AESGCMDecrypter, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &AESGCMDecrypter{aead: aead}, nil
+}
+
+// NewAESGCMDecrypterFromFile creates an AESGCMDecrypter from the base64
+// encoded key stored in the file at path.
+func NewAESGCMDecrypterFromFile(path string) (*AESGCMDecrypter, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read key file '%s': %v", path, err)
+	}
+	return newAESGCMDecrypterFromBase64(string(data))
+}
+
+// NewAESGCMDecrypterFromEnv creates an AESGCMDecrypter from the base64
+// encoded key held by the environment variable name.
+func NewAESGCMDecrypterFromEnv(name string) (*AESGCMDecrypter, error) {
+	encoded, ok := syscall.Getenv(name)
+	if !ok || encoded == "" {
+		return nil, fmt.Errorf("Unable to read key: environment variable '%s' is not set", name)
+	}
+	return newAESGCMDecrypterFromBase64(encoded)
+}
+
+func newAESGCMDecrypterFromBase64(encoded string) (*AESGCMDecrypter, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("Unable to decode key: %v", err)
+	}
+	return NewAESGCMDecrypter(key)
+}
+
+// Decrypt opens ciphertext, the base64 encoding of the nonce followed by the
+// sealed data.
+func (d *AESGCMDecrypter) Decrypt(ciphertext string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("invalid encoding: %v", err)
+	}
+
+	nonceSize := d.aead.NonceSize()
+	if len(data) < nonceSize {
+		return "", errors.New("ciphertext too short")
+	}
+
+	plaintext, err := d.aead.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return "", errors.New("message authentication failed")
+	}
+	return string(plaintext), nil
+}
+
+// Encrypt seals plaintext with a random nonce and returns it ready to be
+// stored in an input source, including EncryptedValuePrefix.
+func (d *AESGCMDecrypter) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, d.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	sealed := d.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return EncryptedValuePrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
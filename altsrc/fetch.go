@@ -0,0 +1,93 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package altsrc
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Fetcher loads the raw contents of an input source identified by an url.
+type Fetcher interface {
+	Fetch(u *url.URL) ([]byte, error)
+}
+
+// FetcherFunc is an adapter to allow the use of ordinary functions as
+// Fetchers.
+type FetcherFunc func(u *url.URL) ([]byte, error)
+
+// Fetch calls f(u).
+func (f FetcherFunc) Fetch(u *url.URL) ([]byte, error) {
+	return f(u)
+}
+
+// stdin is read by the stdin fetcher, replaced in tests
+var stdin io.Reader = os.Stdin
+
+var (
+	fetchersMu sync.RWMutex
+	fetchers   = map[string]Fetcher{
+		"file":  FetcherFunc(fetchFile),
+		"stdin": FetcherFunc(fetchStdin),
+	}
+)
+
+// RegisterFetcher makes f load the input sources whose url has the given
+// scheme, replacing the fetcher previously registered for it. Paths without
+// a scheme are loaded by the "file" fetcher and "-" by the "stdin" fetcher.
+// Registering a nil Fetcher disables the scheme.
+func RegisterFetcher(scheme string, f Fetcher) {
+	fetchersMu.Lock()
+	defer fetchersMu.Unlock()
+
+	scheme = strings.ToLower(scheme)
+	if f == nil {
+		delete(fetchers, scheme)
+		return
+	}
+	fetchers[scheme] = f
+}
+
+func fetch(u *url.URL, filePath string) ([]byte, error) {
+	fetchersMu.RLock()
+	f, ok := fetchers[strings.ToLower(u.Scheme)]
+	fetchersMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("scheme of %s is unsupported", filePath)
+	}
+	return f.Fetch(u)
+}
+
+func fetchFile(u *url.URL) ([]byte, error) {
+	filePath := u.Path
+	if u.Opaque != "" {
+		filePath = u.Opaque
+	}
+
+	if _, notFoundFileErr := os.Stat(filePath); notFoundFileErr != nil {
+		return nil, fmt.Errorf("Cannot read from file: '%s' because it does not exist.", filePath)
+	}
+	return ioutil.ReadFile(filePath)
+}
+
+func fetchStdin(*url.URL) ([]byte, error) {
+	return ioutil.ReadAll(stdin)
+}
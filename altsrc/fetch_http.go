@@ -0,0 +1,98 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !altsrc_nohttp
+// +build !altsrc_nohttp
+
+package altsrc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// DefaultHTTPTimeout bounds remote input source requests when
+	// HTTPFetcher.Timeout is not set
+	DefaultHTTPTimeout = 30 * time.Second
+	// DefaultHTTPMaxBytes limits the size of remote input sources when
+	// HTTPFetcher.MaxBytes is not set
+	DefaultHTTPMaxBytes = 10 << 20
+)
+
+func init() {
+	RegisterFetcher("http", &HTTPFetcher{})
+	RegisterFetcher("https", &HTTPFetcher{})
+}
+
+// HTTPFetcher loads input sources over http and https. It is registered for
+// both schemes unless the package is built with the altsrc_nohttp tag.
+type HTTPFetcher struct {
+	// Client sends the requests, defaults to http.DefaultClient
+	Client *http.Client
+	// Timeout bounds the whole request, defaults to DefaultHTTPTimeout
+	Timeout time.Duration
+	// MaxBytes limits the size of the response body, defaults to
+	// DefaultHTTPMaxBytes
+	MaxBytes int64
+}
+
+// Fetch gets u and returns the response body. Responses with a non-2xx
+// status code or a body larger than MaxBytes are rejected.
+func (f *HTTPFetcher) Fetch(u *url.URL) ([]byte, error) {
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	timeout := f.Timeout
+	if timeout <= 0 {
+		timeout = DefaultHTTPTimeout
+	}
+	maxBytes := f.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultHTTPMaxBytes
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, fmt.Errorf("unable to fetch %s: unexpected status %s", u.Redacted(), res.Status)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(res.Body, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("unable to fetch %s: response exceeds %d bytes", u.Redacted(), maxBytes)
+	}
+	return data, nil
+}
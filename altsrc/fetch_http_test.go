@@ -0,0 +1,60 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !altsrc_nohttp
+// +build !altsrc_nohttp
+
+package altsrc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadDataFromHTTP(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/config.yaml":
+			_, _ = w.Write([]byte("test: 15"))
+		case "/slow.yaml":
+			time.Sleep(200 * time.Millisecond)
+		case "/large.yaml":
+			_, _ = w.Write([]byte(strings.Repeat("a", 32)))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	RegisterFetcher("http", &HTTPFetcher{Client: ts.Client(), Timeout: 50 * time.Millisecond, MaxBytes: 16})
+	defer RegisterFetcher("http", &HTTPFetcher{})
+
+	data, err := loadDataFrom(ts.URL + "/config.yaml")
+	expect(t, err, nil)
+	expect(t, string(data), "test: 15")
+
+	_, err = loadDataFrom(ts.URL + "/missing.yaml")
+	refute(t, err, nil)
+	expect(t, strings.Contains(err.Error(), "unexpected status 404"), true)
+
+	_, err = loadDataFrom(ts.URL + "/slow.yaml")
+	refute(t, err, nil)
+
+	_, err = loadDataFrom(ts.URL + "/large.yaml")
+	refute(t, err, nil)
+	expect(t, strings.Contains(err.Error(), "response exceeds 16 bytes"), true)
+}
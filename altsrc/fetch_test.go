@@ -0,0 +1,70 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package altsrc
+
+import (
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadDataFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "altsrc")
+	expect(t, err, nil)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "current.yaml")
+	_ = ioutil.WriteFile(path, []byte("test: 15"), 0666)
+
+	data, err := loadDataFrom(path)
+	expect(t, err, nil)
+	expect(t, string(data), "test: 15")
+
+	data, err = loadDataFrom((&url.URL{Scheme: "file", Path: path}).String())
+	expect(t, err, nil)
+	expect(t, string(data), "test: 15")
+
+	_, err = loadDataFrom(filepath.Join(dir, "missing.yaml"))
+	refute(t, err, nil)
+}
+
+func TestLoadDataFromStdin(t *testing.T) {
+	defer func(r io.Reader) { stdin = r }(stdin)
+	stdin = strings.NewReader("test: 15")
+
+	data, err := loadDataFrom("-")
+	expect(t, err, nil)
+	expect(t, string(data), "test: 15")
+}
+
+func TestRegisterFetcher(t *testing.T) {
+	_, err := loadDataFrom("vault://secret/config")
+	expect(t, err.Error(), "scheme of vault://secret/config is unsupported")
+
+	RegisterFetcher("vault", FetcherFunc(func(u *url.URL) ([]byte, error) {
+		return []byte(u.Host + u.Path), nil
+	}))
+	data, err := loadDataFrom("vault://secret/config")
+	expect(t, err, nil)
+	expect(t, string(data), "secret/config")
+
+	RegisterFetcher("vault", nil)
+	_, err = loadDataFrom("vault://secret/config")
+	refute(t, err, nil)
+}
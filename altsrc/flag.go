@@ -15,10 +15,13 @@
 package altsrc
 
 import (
+	"flag"
 	"fmt"
 	"path/filepath"
+	"reflect"
 	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/vine-io/cli"
 )
@@ -30,6 +33,41 @@ type FlagInputSourceExtension interface {
 	ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error
 }
 
+// ConfigKeys controls which input source keys a flag is read from. It is
+// embedded in every flag type of this package.
+type ConfigKeys struct {
+	// ConfigKey is the key looked up in the input source, defaults to the
+	// flag name
+	ConfigKey string
+	// ConfigAliases are legacy keys consulted in order when ConfigKey is
+	// missing from the input source. Using one of them prints a deprecation
+	// warning to cli.ErrWriter.
+	ConfigAliases []string
+	// SkipConfig prevents the flag from being set from an input source
+	SkipConfig bool
+}
+
+// configKey returns the key the value of the flag named name should be read
+// from in the given input source.
+func (k *ConfigKeys) configKey(isc InputSourceContext, name string) string {
+	key := name
+	if k.ConfigKey != "" {
+		key = k.ConfigKey
+	}
+	if set, ok := isSet(isc, key); set || !ok {
+		return key
+	}
+
+	for _, alias := range k.ConfigAliases {
+		if set, _ := isSet(isc, alias); set {
+			_, _ = fmt.Fprintf(cli.ErrWriter, "Deprecated config key '%s' in '%s', use '%s' instead\n", alias, isc.Source(), key)
+			return alias
+		}
+	}
+
+	return key
+}
+
 // ApplyInputSourceValues iterates over all provided flags and
 // executes ApplyInputSourceValue on flags implementing the
 // FlagInputSourceExtension interface to initialize these flags
@@ -78,15 +116,20 @@ func InitInputSourceWithContext(flags []cli.Flag, createInputSource func(context
 
 // ApplyInputSourceValue applies a generic value to the flagSet if required
 func (f *GenericFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
-	if f.set != nil {
+	if f.set != nil && !f.SkipConfig {
 		if !context.IsSet(f.Name) && !isEnvVarSet(f.EnvVars) {
-			value, err := isc.Generic(f.GenericFlag.Name)
+			key := f.configKey(isc, f.GenericFlag.Name)
+			value, err := isc.Generic(key)
 			if err != nil {
 				return err
 			}
 			if value != nil {
+				str, err := decryptValue(key, value.String())
+				if err != nil {
+					return err
+				}
 				for _, name := range f.Names() {
-					_ = f.set.Set(name, value.String())
+					_ = f.set.Set(name, str)
 				}
 			}
 		}
@@ -97,14 +140,19 @@ func (f *GenericFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourc
 
 // ApplyInputSourceValue applies a StringSlice value to the flagSet if required
 func (f *StringSliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
-	if f.set != nil {
+	if f.set != nil && !f.SkipConfig {
 		if !context.IsSet(f.Name) && !isEnvVarSet(f.EnvVars) {
-			value, err := isc.StringSlice(f.StringSliceFlag.Name)
+			key := f.configKey(isc, f.StringSliceFlag.Name)
+			value, err := isc.StringSlice(key)
 			if err != nil {
 				return err
 			}
 			if value != nil {
-				var sliceValue cli.StringSlice = *(cli.NewStringSlice(value...))
+				values, err := decryptValues(key, value)
+				if err != nil {
+					return err
+				}
+				var sliceValue cli.StringSlice = *(cli.NewStringSlice(values...))
 				for _, name := range f.Names() {
 					underlyingFlag := f.set.Lookup(name)
 					if underlyingFlag != nil {
@@ -117,11 +165,58 @@ func (f *StringSliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputS
 	return nil
 }
 
+// ApplyInputSourceValue applies a StringMap value to the flagSet if required
+func (f *StringMapFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			key := f.configKey(isc, f.StringMapFlag.Name)
+			value, err := stringMap(isc, key)
+			if err != nil {
+				return err
+			}
+			if value != nil {
+				for k, v := range value {
+					if value[k], err = decryptValue(fmt.Sprintf("%s.%s", key, k), v); err != nil {
+						return err
+					}
+				}
+				serialized := cli.NewStringMap(value).Serialize()
+				for _, name := range f.Names() {
+					_ = f.set.Set(name, serialized)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a GenericMap value to the flagSet if required
+func (f *GenericMapFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			key := f.configKey(isc, f.GenericMapFlag.Name)
+			value, err := stringMap(isc, key)
+			if err != nil {
+				return err
+			}
+			if value != nil {
+				for k, v := range value {
+					if value[k], err = decryptValue(fmt.Sprintf("%s.%s", key, k), v); err != nil {
+						return err
+					}
+				}
+				return setFlagValue(f.set, f, isc, key, cli.NewStringMap(value).Serialize(), mapValues(value)...)
+			}
+		}
+	}
+	return nil
+}
+
 // ApplyInputSourceValue applies a IntSlice value if required
 func (f *IntSliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
-	if f.set != nil {
+	if f.set != nil && !f.SkipConfig {
 		if !context.IsSet(f.Name) && !isEnvVarSet(f.EnvVars) {
-			value, err := isc.IntSlice(f.IntSliceFlag.Name)
+			value, err := isc.IntSlice(f.configKey(isc, f.IntSliceFlag.Name))
 			if err != nil {
 				return err
 			}
@@ -139,11 +234,115 @@ func (f *IntSliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputSour
 	return nil
 }
 
+// ApplyInputSourceValue applies a UintSlice value to the flagSet if required
+func (f *UintSliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !context.IsSet(f.Name) && !isEnvVarSet(f.EnvVars) {
+			key := f.configKey(isc, f.UintSliceFlag.Name)
+			value, err := isc.IntSlice(key)
+			if err != nil {
+				return err
+			}
+			if value != nil {
+				values := make([]uint, len(value))
+				for i, v := range value {
+					if v < 0 {
+						return fmt.Errorf("unable to apply %s from %s: negative value %d", key, isc.Source(), v)
+					}
+					values[i] = uint(v)
+				}
+				return setFlagValue(f.set, f, isc, key, cli.NewUintSlice(values...).Serialize())
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a Uint64Slice value to the flagSet if required
+func (f *Uint64SliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !context.IsSet(f.Name) && !isEnvVarSet(f.EnvVars) {
+			key := f.configKey(isc, f.Uint64SliceFlag.Name)
+			value, err := isc.IntSlice(key)
+			if err != nil {
+				return err
+			}
+			if value != nil {
+				values := make([]uint64, len(value))
+				for i, v := range value {
+					if v < 0 {
+						return fmt.Errorf("unable to apply %s from %s: negative value %d", key, isc.Source(), v)
+					}
+					values[i] = uint64(v)
+				}
+				return setFlagValue(f.set, f, isc, key, cli.NewUint64Slice(values...).Serialize())
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a DurationSlice value to the flagSet if required
+func (f *DurationSliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !context.IsSet(f.Name) && !isEnvVarSet(f.EnvVars) {
+			key := f.configKey(isc, f.DurationSliceFlag.Name)
+			value, err := isc.StringSlice(key)
+			if err != nil {
+				return err
+			}
+			if value != nil {
+				values := make([]time.Duration, len(value))
+				for i, v := range value {
+					if values[i], err = time.ParseDuration(v); err != nil {
+						return fmt.Errorf("unable to apply %s from %s: %v", key, isc.Source(), err)
+					}
+				}
+				return setFlagValue(f.set, f, isc, key, cli.NewDurationSlice(values...).Serialize())
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a TimestampSlice value to the flagSet if required
+func (f *TimestampSliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !context.IsSet(f.Name) && !isEnvVarSet(f.EnvVars) {
+			key := f.configKey(isc, f.TimestampSliceFlag.Name)
+			value, err := isc.StringSlice(key)
+			if err != nil {
+				return err
+			}
+			if value != nil {
+				// parsed like on the command line, each value as a whole
+				parsed := &cli.Timestamp{}
+				parsed.SetLayout(f.Layout)
+				parsed.SetLayouts(f.Layouts...)
+				parsed.SetLocation(f.Timezone)
+				values := make([]time.Time, len(value))
+				for i, v := range value {
+					if err := parsed.Set(v); err != nil {
+						err = cli.RedactFlagError(f, err, v)
+						return fmt.Errorf("unable to apply %s from %s: %v", key, isc.Source(), err)
+					}
+					values[i] = *parsed.Value()
+				}
+				return setFlagValue(f.set, f, isc, key, cli.NewTimestampSlice(values...).Serialize())
+			}
+		}
+	}
+	return nil
+}
+
 // ApplyInputSourceValue applies a Bool value to the flagSet if required
 func (f *BoolFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
-	if f.set != nil {
+	if f.set != nil && !f.SkipConfig {
 		if !context.IsSet(f.Name) && !isEnvVarSet(f.EnvVars) {
-			value, err := isc.Bool(f.BoolFlag.Name)
+			if f.Lenient || lenientBools(context) {
+				return applyLenientBoolValue(f.set, f, isc, f.configKey(isc, f.BoolFlag.Name))
+			}
+			value, err := isc.Bool(f.configKey(isc, f.BoolFlag.Name))
 			if err != nil {
 				return err
 			}
@@ -157,14 +356,47 @@ func (f *BoolFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceCo
 	return nil
 }
 
+// ApplyInputSourceValue applies an OptionalBool value to the flagSet if
+// required. Unlike a Bool value, false is applied as well, as long as the
+// input source implements InputSourceKeyChecker and holds the key.
+func (f *OptionalBoolFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !context.IsSet(f.Name) && !isEnvVarSet(f.EnvVars) {
+			key := f.configKey(isc, f.OptionalBoolFlag.Name)
+			set, ok := isSet(isc, key)
+			if ok && !set {
+				return nil
+			}
+			if f.Lenient || lenientBools(context) {
+				return applyLenientBoolValue(f.set, f, isc, key)
+			}
+			value, err := isc.Bool(key)
+			if err != nil {
+				return err
+			}
+			if !ok && !value {
+				return nil
+			}
+			for _, name := range f.Names() {
+				_ = f.set.Set(name, strconv.FormatBool(value))
+			}
+		}
+	}
+	return nil
+}
+
 // ApplyInputSourceValue applies a String value to the flagSet if required
 func (f *StringFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
-	if f.set != nil {
+	if f.set != nil && !f.SkipConfig {
 		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
-			value, err := isc.String(f.StringFlag.Name)
+			key := f.configKey(isc, f.StringFlag.Name)
+			value, err := isc.String(key)
 			if err != nil {
 				return err
 			}
+			if value, err = decryptValue(key, value); err != nil {
+				return err
+			}
 			if value != "" {
 				for _, name := range f.Names() {
 					_ = f.set.Set(name, value)
@@ -177,27 +409,49 @@ func (f *StringFlag) ApplyInputSourceValue(context *cli.Context, isc InputSource
 
 // ApplyInputSourceValue applies a Path value to the flagSet if required
 func (f *PathFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
-	if f.set != nil {
+	if f.set != nil && !f.SkipConfig {
 		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
-			value, err := isc.String(f.PathFlag.Name)
+			key := f.configKey(isc, f.PathFlag.Name)
+			value, err := isc.String(key)
 			if err != nil {
 				return err
 			}
+			if value, err = decryptValue(key, value); err != nil {
+				return err
+			}
 			if value != "" {
-				for _, name := range f.Names() {
-
-					if !filepath.IsAbs(value) && isc.Source() != "" {
-						basePathAbs, err := filepath.Abs(isc.Source())
-						if err != nil {
-							return err
-						}
-
-						value = filepath.Join(filepath.Dir(basePathAbs), value)
-					}
+				if value, err = resolvePath(isc, value, f.Expand); err != nil {
+					return err
+				}
+				return setFlagValue(f.set, f, isc, key, value)
+			}
+		}
+	}
+	return nil
+}
 
-					_ = f.set.Set(name, value)
+// ApplyInputSourceValue applies a PathSlice value to the flagSet if required
+func (f *PathSliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			key := f.configKey(isc, f.PathSliceFlag.Name)
+			value, err := isc.StringSlice(key)
+			if err != nil {
+				return err
+			}
+			if value == nil {
+				return nil
+			}
+			if value, err = decryptValues(key, value); err != nil {
+				return err
+			}
+			paths := make([]string, len(value))
+			for i, path := range value {
+				if paths[i], err = resolvePath(isc, path, f.Expand); err != nil {
+					return err
 				}
 			}
+			return setFlagValue(f.set, f, isc, key, cli.NewStringSlice(paths...).Serialize())
 		}
 	}
 	return nil
@@ -205,16 +459,70 @@ func (f *PathFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceCo
 
 // ApplyInputSourceValue applies a int value to the flagSet if required
 func (f *IntFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
-	if f.set != nil {
+	if f.set != nil && !f.SkipConfig {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			return applyIntegerValue(f.set, f, isc, f.configKey(isc, f.IntFlag.Name))
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies an int64 value to the flagSet if required
+func (f *Int64Flag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			return applyIntegerValue(f.set, f, isc, f.configKey(isc, f.Int64Flag.Name))
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a uint value to the flagSet if required
+func (f *UintFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			return applyIntegerValue(f.set, f, isc, f.configKey(isc, f.UintFlag.Name))
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a uint64 value to the flagSet if required
+func (f *Uint64Flag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			return applyIntegerValue(f.set, f, isc, f.configKey(isc, f.Uint64Flag.Name))
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a count value to the flagSet if required
+func (f *CountFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
 		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
-			value, err := isc.Int(f.IntFlag.Name)
+			return applyIntegerValue(f.set, f, isc, f.configKey(isc, f.CountFlag.Name))
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a byte size value to the flagSet if required.
+// The value may be a string with a unit or a number of bytes.
+func (f *ByteSizeFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			key := f.configKey(isc, f.ByteSizeFlag.Name)
+			value, err := isc.String(key)
 			if err != nil {
-				return err
-			}
-			if value > 0 {
-				for _, name := range f.Names() {
-					_ = f.set.Set(name, strconv.FormatInt(int64(value), 10))
+				n, ierr := isc.Int(key)
+				if ierr != nil {
+					return err
 				}
+				value = strconv.Itoa(n)
+			}
+			if value != "" {
+				return setFlagValue(f.set, f, isc, key, value)
 			}
 		}
 	}
@@ -223,9 +531,9 @@ func (f *IntFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceCon
 
 // ApplyInputSourceValue applies a Duration value to the flagSet if required
 func (f *DurationFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
-	if f.set != nil {
+	if f.set != nil && !f.SkipConfig {
 		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
-			value, err := isc.Duration(f.DurationFlag.Name)
+			value, err := isc.Duration(f.configKey(isc, f.DurationFlag.Name))
 			if err != nil {
 				return err
 			}
@@ -241,9 +549,9 @@ func (f *DurationFlag) ApplyInputSourceValue(context *cli.Context, isc InputSour
 
 // ApplyInputSourceValue applies a Float64 value to the flagSet if required
 func (f *Float64Flag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
-	if f.set != nil {
+	if f.set != nil && !f.SkipConfig {
 		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
-			value, err := isc.Float64(f.Float64Flag.Name)
+			value, err := isc.Float64(f.configKey(isc, f.Float64Flag.Name))
 			if err != nil {
 				return err
 			}
@@ -258,6 +566,290 @@ func (f *Float64Flag) ApplyInputSourceValue(context *cli.Context, isc InputSourc
 	return nil
 }
 
+// ApplyInputSourceValue applies an IP value to the flagSet if required
+func (f *IPFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			return applyStringValue(f.set, f, isc, f.configKey(isc, f.IPFlag.Name))
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies an IPSlice value to the flagSet if required
+func (f *IPSliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			return applyStringSliceValue(f.set, f, isc, f.configKey(isc, f.IPSliceFlag.Name))
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies an IPNet value to the flagSet if required
+func (f *IPNetFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			return applyStringValue(f.set, f, isc, f.configKey(isc, f.IPNetFlag.Name))
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies an IPNetSlice value to the flagSet if required
+func (f *IPNetSliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			return applyStringSliceValue(f.set, f, isc, f.configKey(isc, f.IPNetSliceFlag.Name))
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a HostPort value to the flagSet if required
+func (f *HostPortFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			return applyStringValue(f.set, f, isc, f.configKey(isc, f.HostPortFlag.Name))
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a HostPortSlice value to the flagSet if required
+func (f *HostPortSliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			return applyStringSliceValue(f.set, f, isc, f.configKey(isc, f.HostPortSliceFlag.Name))
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a URL value to the flagSet if required
+func (f *URLFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			return applyStringValue(f.set, f, isc, f.configKey(isc, f.URLFlag.Name))
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a URLSlice value to the flagSet if required
+func (f *URLSliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			return applyStringSliceValue(f.set, f, isc, f.configKey(isc, f.URLSliceFlag.Name))
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies an input file path value to the flagSet if required
+func (f *InputFileFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			return applyFilePathValue(f.set, f, isc, f.configKey(isc, f.InputFileFlag.Name))
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies an output file path value to the flagSet if required
+func (f *OutputFileFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			return applyFilePathValue(f.set, f, isc, f.configKey(isc, f.OutputFileFlag.Name))
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a bytes file path value to the flagSet if required
+func (f *BytesFileFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil && !f.SkipConfig {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			return applyFilePathValue(f.set, f, isc, f.configKey(isc, f.BytesFileFlag.Name))
+		}
+	}
+	return nil
+}
+
+// valueLookup is implemented by the input sources giving access to the raw
+// values they hold
+type valueLookup interface {
+	lookup(name string) (interface{}, bool)
+}
+
+// lookupInteger returns the value of key formatted in decimal when the
+// input source holds a number of an integer type for it
+func lookupInteger(isc InputSourceContext, key string) (string, bool) {
+	src, ok := isc.(valueLookup)
+	if !ok {
+		return "", false
+	}
+	raw, exists := src.lookup(key)
+	if !exists {
+		return "", false
+	}
+	switch reflect.ValueOf(raw).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprint(raw), true
+	}
+	return "", false
+}
+
+// applyIntegerValue sets the integer value of key on the integer flag f.
+// Strings are parsed by the flag, so that they accept the same syntax as on
+// the command line, e.g. 0x1F or 10k, and numbers of any integer type are
+// checked against the size of the flag.
+func applyIntegerValue(set *flag.FlagSet, f cli.Flag, isc InputSourceContext, key string) error {
+	value, err := isc.String(key)
+	if err != nil {
+		var ok bool
+		if value, ok = lookupInteger(isc, key); !ok {
+			n, ierr := isc.Int(key)
+			if ierr != nil {
+				return ierr
+			}
+			value = strconv.Itoa(n)
+		}
+	}
+	if value == "" {
+		return nil
+	}
+	return setFlagValue(set, f, isc, key, value)
+}
+
+// lenientBools returns whether the app running context has LenientBools
+func lenientBools(context *cli.Context) bool {
+	return context != nil && context.App != nil && context.App.LenientBools
+}
+
+// applyLenientBoolValue sets the bool value of key on the lenient bool flag
+// f. Besides real bools, strings such as "on" or "no" and the numbers 1 and
+// 0 are parsed by the flag.
+func applyLenientBoolValue(set *flag.FlagSet, f cli.Flag, isc InputSourceContext, key string) error {
+	value, err := isc.String(key)
+	if err != nil {
+		b, berr := isc.Bool(key)
+		if berr == nil {
+			value = strconv.FormatBool(b)
+		} else {
+			var ok bool
+			if value, ok = lookupInteger(isc, key); !ok {
+				return berr
+			}
+		}
+	}
+	if value == "" {
+		return nil
+	}
+	return setFlagValue(set, f, isc, key, value)
+}
+
+// applyStringValue sets the string value of key on the flag f, returning the
+// error of a value the flag fails to parse
+func applyStringValue(set *flag.FlagSet, f cli.Flag, isc InputSourceContext, key string) error {
+	value, err := isc.String(key)
+	if err != nil {
+		return err
+	}
+	if value, err = decryptValue(key, value); err != nil {
+		return err
+	}
+	if value == "" {
+		return nil
+	}
+	return setFlagValue(set, f, isc, key, value)
+}
+
+// applyStringSliceValue sets the string slice value of key on the slice flag
+// f. The values are passed serialized, so that each one is parsed as a whole
+// even when it contains commas.
+func applyStringSliceValue(set *flag.FlagSet, f cli.Flag, isc InputSourceContext, key string) error {
+	value, err := isc.StringSlice(key)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		return nil
+	}
+	if value, err = decryptValues(key, value); err != nil {
+		return err
+	}
+	return setFlagValue(set, f, isc, key, cli.NewStringSlice(value...).Serialize(), value...)
+}
+
+// applyFilePathValue sets the file path value of key on the file flag f. A
+// relative path is resolved from the directory of the input source, "-" is
+// kept for stdin or stdout.
+func applyFilePathValue(set *flag.FlagSet, f cli.Flag, isc InputSourceContext, key string) error {
+	value, err := isc.String(key)
+	if err != nil {
+		return err
+	}
+	if value, err = decryptValue(key, value); err != nil {
+		return err
+	}
+	if value == "" {
+		return nil
+	}
+	if value != "-" {
+		if value, err = resolveSourcePath(isc, value); err != nil {
+			return err
+		}
+	}
+	return setFlagValue(set, f, isc, key, value)
+}
+
+// mapValues returns the values of m, which a sensitive flag hides from errors
+func mapValues(m map[string]string) []string {
+	values := make([]string, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
+
+// resolvePath resolves a relative path from the directory of the input
+// source. With expand, the path is relative if it still is once expanded, but
+// it is returned unexpanded since the flag expands the values it is set to.
+func resolvePath(isc InputSourceContext, path string, expand bool) (string, error) {
+	if expand && filepath.IsAbs(cli.ExpandPath(path)) {
+		return path, nil
+	}
+	return resolveSourcePath(isc, path)
+}
+
+// resolveSourcePath resolves a relative path from the directory of the input
+// source
+func resolveSourcePath(isc InputSourceContext, path string) (string, error) {
+	if filepath.IsAbs(path) || isc.Source() == "" {
+		return path, nil
+	}
+	basePathAbs, err := filepath.Abs(isc.Source())
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(basePathAbs), path), nil
+}
+
+// setFlagValue sets value on the names of f, returning the error of a value
+// the flag fails to parse. The value and the values it is made of are
+// redacted from the error of a sensitive flag.
+func setFlagValue(set *flag.FlagSet, f cli.Flag, isc InputSourceContext, key, value string, values ...string) error {
+	for _, name := range f.Names() {
+		if err := set.Set(name, value); err != nil {
+			err = cli.RedactFlagError(f, err, append([]string{value}, values...)...)
+			return fmt.Errorf("unable to apply %s from %s: %v", key, isc.Source(), err)
+		}
+	}
+	return nil
+}
+
 func isEnvVarSet(envVars []string) bool {
 	for _, envVar := range envVars {
 		if _, ok := syscall.Getenv(envVar); ok {
@@ -26,6 +26,7 @@ import (
 // for other values to be specified
 type BoolFlag struct {
 	*cli.BoolFlag
+	ConfigKeys
 	set *flag.FlagSet
 }
 
@@ -45,6 +46,7 @@ func (f *BoolFlag) Apply(set *flag.FlagSet) error {
 // for other values to be specified
 type DurationFlag struct {
 	*cli.DurationFlag
+	ConfigKeys
 	set *flag.FlagSet
 }
 
@@ -64,6 +66,7 @@ func (f *DurationFlag) Apply(set *flag.FlagSet) error {
 // for other values to be specified
 type Float64Flag struct {
 	*cli.Float64Flag
+	ConfigKeys
 	set *flag.FlagSet
 }
 
@@ -83,6 +86,7 @@ func (f *Float64Flag) Apply(set *flag.FlagSet) error {
 // for other values to be specified
 type GenericFlag struct {
 	*cli.GenericFlag
+	ConfigKeys
 	set *flag.FlagSet
 }
 
@@ -102,6 +106,7 @@ func (f *GenericFlag) Apply(set *flag.FlagSet) error {
 // for other values to be specified
 type Int64Flag struct {
 	*cli.Int64Flag
+	ConfigKeys
 	set *flag.FlagSet
 }
 
@@ -121,6 +126,7 @@ func (f *Int64Flag) Apply(set *flag.FlagSet) error {
 // for other values to be specified
 type IntFlag struct {
 	*cli.IntFlag
+	ConfigKeys
 	set *flag.FlagSet
 }
 
@@ -140,6 +146,7 @@ func (f *IntFlag) Apply(set *flag.FlagSet) error {
 // for other values to be specified
 type IntSliceFlag struct {
 	*cli.IntSliceFlag
+	ConfigKeys
 	set *flag.FlagSet
 }
 
@@ -159,6 +166,7 @@ func (f *IntSliceFlag) Apply(set *flag.FlagSet) error {
 // for other values to be specified
 type Int64SliceFlag struct {
 	*cli.Int64SliceFlag
+	ConfigKeys
 	set *flag.FlagSet
 }
 
@@ -178,6 +186,7 @@ func (f *Int64SliceFlag) Apply(set *flag.FlagSet) error {
 // for other values to be specified
 type Float64SliceFlag struct {
 	*cli.Float64SliceFlag
+	ConfigKeys
 	set *flag.FlagSet
 }
 
@@ -197,6 +206,7 @@ func (f *Float64SliceFlag) Apply(set *flag.FlagSet) error {
 // for other values to be specified
 type StringFlag struct {
 	*cli.StringFlag
+	ConfigKeys
 	set *flag.FlagSet
 }
 
@@ -216,6 +226,7 @@ func (f *StringFlag) Apply(set *flag.FlagSet) error {
 // for other values to be specified
 type PathFlag struct {
 	*cli.PathFlag
+	ConfigKeys
 	set *flag.FlagSet
 }
 
@@ -235,6 +246,7 @@ func (f *PathFlag) Apply(set *flag.FlagSet) error {
 // for other values to be specified
 type StringSliceFlag struct {
 	*cli.StringSliceFlag
+	ConfigKeys
 	set *flag.FlagSet
 }
 
@@ -254,6 +266,7 @@ func (f *StringSliceFlag) Apply(set *flag.FlagSet) error {
 // for other values to be specified
 type Uint64Flag struct {
 	*cli.Uint64Flag
+	ConfigKeys
 	set *flag.FlagSet
 }
 
@@ -273,6 +286,7 @@ func (f *Uint64Flag) Apply(set *flag.FlagSet) error {
 // for other values to be specified
 type UintFlag struct {
 	*cli.UintFlag
+	ConfigKeys
 	set *flag.FlagSet
 }
 
@@ -287,3 +301,423 @@ func (f *UintFlag) Apply(set *flag.FlagSet) error {
 	f.set = set
 	return f.UintFlag.Apply(set)
 }
+
+// OptionalBoolFlag is the flag type that wraps cli.OptionalBoolFlag to allow
+// for other values to be specified
+type OptionalBoolFlag struct {
+	*cli.OptionalBoolFlag
+	ConfigKeys
+	set *flag.FlagSet
+}
+
+// NewOptionalBoolFlag creates a new OptionalBoolFlag
+func NewOptionalBoolFlag(fl *cli.OptionalBoolFlag) *OptionalBoolFlag {
+	return &OptionalBoolFlag{OptionalBoolFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped OptionalBoolFlag.Apply
+func (f *OptionalBoolFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.OptionalBoolFlag.Apply(set)
+}
+
+// CountFlag is the flag type that wraps cli.CountFlag to allow
+// for other values to be specified
+type CountFlag struct {
+	*cli.CountFlag
+	ConfigKeys
+	set *flag.FlagSet
+}
+
+// NewCountFlag creates a new CountFlag
+func NewCountFlag(fl *cli.CountFlag) *CountFlag {
+	return &CountFlag{CountFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped CountFlag.Apply
+func (f *CountFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.CountFlag.Apply(set)
+}
+
+// StringMapFlag is the flag type that wraps cli.StringMapFlag to allow
+// for other values to be specified
+type StringMapFlag struct {
+	*cli.StringMapFlag
+	ConfigKeys
+	set *flag.FlagSet
+}
+
+// NewStringMapFlag creates a new StringMapFlag
+func NewStringMapFlag(fl *cli.StringMapFlag) *StringMapFlag {
+	return &StringMapFlag{StringMapFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped StringMapFlag.Apply
+func (f *StringMapFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.StringMapFlag.Apply(set)
+}
+
+// GenericMapFlag is the flag type that wraps cli.GenericMapFlag to allow
+// for other values to be specified
+type GenericMapFlag struct {
+	*cli.GenericMapFlag
+	ConfigKeys
+	set *flag.FlagSet
+}
+
+// NewGenericMapFlag creates a new GenericMapFlag
+func NewGenericMapFlag(fl *cli.GenericMapFlag) *GenericMapFlag {
+	return &GenericMapFlag{GenericMapFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped GenericMapFlag.Apply
+func (f *GenericMapFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.GenericMapFlag.Apply(set)
+}
+
+// ByteSizeFlag is the flag type that wraps cli.ByteSizeFlag to allow
+// for other values to be specified
+type ByteSizeFlag struct {
+	*cli.ByteSizeFlag
+	ConfigKeys
+	set *flag.FlagSet
+}
+
+// NewByteSizeFlag creates a new ByteSizeFlag
+func NewByteSizeFlag(fl *cli.ByteSizeFlag) *ByteSizeFlag {
+	return &ByteSizeFlag{ByteSizeFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped ByteSizeFlag.Apply
+func (f *ByteSizeFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.ByteSizeFlag.Apply(set)
+}
+
+// IPFlag is the flag type that wraps cli.IPFlag to allow
+// for other values to be specified
+type IPFlag struct {
+	*cli.IPFlag
+	ConfigKeys
+	set *flag.FlagSet
+}
+
+// NewIPFlag creates a new IPFlag
+func NewIPFlag(fl *cli.IPFlag) *IPFlag {
+	return &IPFlag{IPFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped IPFlag.Apply
+func (f *IPFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.IPFlag.Apply(set)
+}
+
+// IPSliceFlag is the flag type that wraps cli.IPSliceFlag to allow
+// for other values to be specified
+type IPSliceFlag struct {
+	*cli.IPSliceFlag
+	ConfigKeys
+	set *flag.FlagSet
+}
+
+// NewIPSliceFlag creates a new IPSliceFlag
+func NewIPSliceFlag(fl *cli.IPSliceFlag) *IPSliceFlag {
+	return &IPSliceFlag{IPSliceFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped IPSliceFlag.Apply
+func (f *IPSliceFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.IPSliceFlag.Apply(set)
+}
+
+// IPNetFlag is the flag type that wraps cli.IPNetFlag to allow
+// for other values to be specified
+type IPNetFlag struct {
+	*cli.IPNetFlag
+	ConfigKeys
+	set *flag.FlagSet
+}
+
+// NewIPNetFlag creates a new IPNetFlag
+func NewIPNetFlag(fl *cli.IPNetFlag) *IPNetFlag {
+	return &IPNetFlag{IPNetFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped IPNetFlag.Apply
+func (f *IPNetFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.IPNetFlag.Apply(set)
+}
+
+// IPNetSliceFlag is the flag type that wraps cli.IPNetSliceFlag to allow
+// for other values to be specified
+type IPNetSliceFlag struct {
+	*cli.IPNetSliceFlag
+	ConfigKeys
+	set *flag.FlagSet
+}
+
+// NewIPNetSliceFlag creates a new IPNetSliceFlag
+func NewIPNetSliceFlag(fl *cli.IPNetSliceFlag) *IPNetSliceFlag {
+	return &IPNetSliceFlag{IPNetSliceFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped IPNetSliceFlag.Apply
+func (f *IPNetSliceFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.IPNetSliceFlag.Apply(set)
+}
+
+// HostPortFlag is the flag type that wraps cli.HostPortFlag to allow
+// for other values to be specified
+type HostPortFlag struct {
+	*cli.HostPortFlag
+	ConfigKeys
+	set *flag.FlagSet
+}
+
+// NewHostPortFlag creates a new HostPortFlag
+func NewHostPortFlag(fl *cli.HostPortFlag) *HostPortFlag {
+	return &HostPortFlag{HostPortFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped HostPortFlag.Apply
+func (f *HostPortFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.HostPortFlag.Apply(set)
+}
+
+// HostPortSliceFlag is the flag type that wraps cli.HostPortSliceFlag to allow
+// for other values to be specified
+type HostPortSliceFlag struct {
+	*cli.HostPortSliceFlag
+	ConfigKeys
+	set *flag.FlagSet
+}
+
+// NewHostPortSliceFlag creates a new HostPortSliceFlag
+func NewHostPortSliceFlag(fl *cli.HostPortSliceFlag) *HostPortSliceFlag {
+	return &HostPortSliceFlag{HostPortSliceFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped HostPortSliceFlag.Apply
+func (f *HostPortSliceFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.HostPortSliceFlag.Apply(set)
+}
+
+// URLFlag is the flag type that wraps cli.URLFlag to allow
+// for other values to be specified
+type URLFlag struct {
+	*cli.URLFlag
+	ConfigKeys
+	set *flag.FlagSet
+}
+
+// NewURLFlag creates a new URLFlag
+func NewURLFlag(fl *cli.URLFlag) *URLFlag {
+	return &URLFlag{URLFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped URLFlag.Apply
+func (f *URLFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.URLFlag.Apply(set)
+}
+
+// URLSliceFlag is the flag type that wraps cli.URLSliceFlag to allow
+// for other values to be specified
+type URLSliceFlag struct {
+	*cli.URLSliceFlag
+	ConfigKeys
+	set *flag.FlagSet
+}
+
+// NewURLSliceFlag creates a new URLSliceFlag
+func NewURLSliceFlag(fl *cli.URLSliceFlag) *URLSliceFlag {
+	return &URLSliceFlag{URLSliceFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped URLSliceFlag.Apply
+func (f *URLSliceFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.URLSliceFlag.Apply(set)
+}
+
+// UintSliceFlag is the flag type that wraps cli.UintSliceFlag to allow
+// for other values to be specified
+type UintSliceFlag struct {
+	*cli.UintSliceFlag
+	ConfigKeys
+	set *flag.FlagSet
+}
+
+// NewUintSliceFlag creates a new UintSliceFlag
+func NewUintSliceFlag(fl *cli.UintSliceFlag) *UintSliceFlag {
+	return &UintSliceFlag{UintSliceFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped UintSliceFlag.Apply
+func (f *UintSliceFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.UintSliceFlag.Apply(set)
+}
+
+// Uint64SliceFlag is the flag type that wraps cli.Uint64SliceFlag to allow
+// for other values to be specified
+type Uint64SliceFlag struct {
+	*cli.Uint64SliceFlag
+	ConfigKeys
+	set *flag.FlagSet
+}
+
+// NewUint64SliceFlag creates a new Uint64SliceFlag
+func NewUint64SliceFlag(fl *cli.Uint64SliceFlag) *Uint64SliceFlag {
+	return &Uint64SliceFlag{Uint64SliceFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped Uint64SliceFlag.Apply
+func (f *Uint64SliceFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.Uint64SliceFlag.Apply(set)
+}
+
+// DurationSliceFlag is the flag type that wraps cli.DurationSliceFlag to allow
+// for other values to be specified
+type DurationSliceFlag struct {
+	*cli.DurationSliceFlag
+	ConfigKeys
+	set *flag.FlagSet
+}
+
+// NewDurationSliceFlag creates a new DurationSliceFlag
+func NewDurationSliceFlag(fl *cli.DurationSliceFlag) *DurationSliceFlag {
+	return &DurationSliceFlag{DurationSliceFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped DurationSliceFlag.Apply
+func (f *DurationSliceFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.DurationSliceFlag.Apply(set)
+}
+
+// TimestampSliceFlag is the flag type that wraps cli.TimestampSliceFlag to allow
+// for other values to be specified
+type TimestampSliceFlag struct {
+	*cli.TimestampSliceFlag
+	ConfigKeys
+	set *flag.FlagSet
+}
+
+// NewTimestampSliceFlag creates a new TimestampSliceFlag
+func NewTimestampSliceFlag(fl *cli.TimestampSliceFlag) *TimestampSliceFlag {
+	return &TimestampSliceFlag{TimestampSliceFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped TimestampSliceFlag.Apply
+func (f *TimestampSliceFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.TimestampSliceFlag.Apply(set)
+}
+
+// InputFileFlag is the flag type that wraps cli.InputFileFlag to allow
+// for other values to be specified
+type InputFileFlag struct {
+	*cli.InputFileFlag
+	ConfigKeys
+	set *flag.FlagSet
+}
+
+// NewInputFileFlag creates a new InputFileFlag
+func NewInputFileFlag(fl *cli.InputFileFlag) *InputFileFlag {
+	return &InputFileFlag{InputFileFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped InputFileFlag.Apply
+func (f *InputFileFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.InputFileFlag.Apply(set)
+}
+
+// OutputFileFlag is the flag type that wraps cli.OutputFileFlag to allow
+// for other values to be specified
+type OutputFileFlag struct {
+	*cli.OutputFileFlag
+	ConfigKeys
+	set *flag.FlagSet
+}
+
+// NewOutputFileFlag creates a new OutputFileFlag
+func NewOutputFileFlag(fl *cli.OutputFileFlag) *OutputFileFlag {
+	return &OutputFileFlag{OutputFileFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped OutputFileFlag.Apply
+func (f *OutputFileFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.OutputFileFlag.Apply(set)
+}
+
+// BytesFileFlag is the flag type that wraps cli.BytesFileFlag to allow
+// for other values to be specified
+type BytesFileFlag struct {
+	*cli.BytesFileFlag
+	ConfigKeys
+	set *flag.FlagSet
+}
+
+// NewBytesFileFlag creates a new BytesFileFlag
+func NewBytesFileFlag(fl *cli.BytesFileFlag) *BytesFileFlag {
+	return &BytesFileFlag{BytesFileFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped BytesFileFlag.Apply
+func (f *BytesFileFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.BytesFileFlag.Apply(set)
+}
+
+// PathSliceFlag is the flag type that wraps cli.PathSliceFlag to allow
+// for other values to be specified
+type PathSliceFlag struct {
+	*cli.PathSliceFlag
+	ConfigKeys
+	set *flag.FlagSet
+}
+
+// NewPathSliceFlag creates a new PathSliceFlag
+func NewPathSliceFlag(fl *cli.PathSliceFlag) *PathSliceFlag {
+	return &PathSliceFlag{PathSliceFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped PathSliceFlag.Apply
+func (f *PathSliceFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.PathSliceFlag.Apply(set)
+}
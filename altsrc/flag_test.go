@@ -17,7 +17,10 @@ package altsrc
 import (
 	"flag"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
@@ -232,6 +235,134 @@ func TestPathApplyInputSourceMethodEnvVarSet(t *testing.T) {
 	expect(t, "goodbye", c.String("test"))
 }
 
+func TestIntegerApplyInputSourceMethodSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:     NewInt64Flag(&cli.Int64Flag{Name: "test"}),
+		FlagName: "test",
+		MapValue: int64(-15),
+	})
+	expect(t, c.Int64("test"), int64(-15))
+
+	c = runTest(t, testApplyInputSource{
+		Flag:     NewUint64Flag(&cli.Uint64Flag{Name: "test"}),
+		FlagName: "test",
+		MapValue: uint64(18446744073709551615),
+	})
+	expect(t, c.Uint64("test"), uint64(18446744073709551615))
+
+	c = runTest(t, testApplyInputSource{
+		Flag:     NewUintFlag(&cli.UintFlag{Name: "test", SISuffixes: true}),
+		FlagName: "test",
+		MapValue: "10k",
+	})
+	expect(t, c.Uint("test"), uint(10000))
+
+	c = runTest(t, testApplyInputSource{
+		Flag:     NewIntFlag(&cli.IntFlag{Name: "test"}),
+		FlagName: "test",
+		MapValue: "0x1F",
+	})
+	expect(t, c.Int("test"), 31)
+}
+
+func TestIntegerApplyInputSourceMethodInvalid(t *testing.T) {
+	fl := NewUintFlag(&cli.UintFlag{Name: "test"})
+	set := flag.NewFlagSet("test", 0)
+	_ = fl.Apply(set)
+
+	c := cli.NewContext(nil, set, nil)
+	err := fl.ApplyInputSourceValue(c, &MapInputSource{valueMap: map[interface{}]interface{}{"test": -1}})
+	expect(t, err.Error(), `unable to apply test from : "-1" is out of range for a 64-bit unsigned integer`)
+}
+
+func TestPathApplyInputSourceMethodInvalid(t *testing.T) {
+	fl := NewPathFlag(&cli.PathFlag{Name: "test", MustExist: true})
+	set := flag.NewFlagSet("test", 0)
+	_ = fl.Apply(set)
+
+	c := cli.NewContext(nil, set, nil)
+	err := fl.ApplyInputSourceValue(c, &MapInputSource{file: "/path/to/source/file", valueMap: map[interface{}]interface{}{"test": "hello"}})
+	expect(t, err.Error(), "unable to apply test from /path/to/source/file: /path/to/source/hello does not exist")
+}
+
+func TestPathSliceApplyInputSourceMethodSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:       NewPathSliceFlag(&cli.PathSliceFlag{Name: "test"}),
+		FlagName:   "test",
+		MapValue:   []interface{}{"hello", "/abs/world"},
+		SourcePath: "/path/to/source/file",
+	})
+
+	expected := []string{"/path/to/source/hello", "/abs/world"}
+	if runtime.GOOS == "windows" {
+		expected = []string{`D:\path\to\source\hello`, "/abs/world"}
+	}
+	expect(t, c.PathSlice("test"), expected)
+}
+
+func TestPathApplyInputSourceMethodExpandOnce(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "$ALTSRC_TEST_NAME")
+	_ = os.Setenv("ALTSRC_TEST_DIR", dir)
+	_ = os.Setenv("ALTSRC_TEST_NAME", "expanded")
+	defer os.Unsetenv("ALTSRC_TEST_DIR")
+	defer os.Unsetenv("ALTSRC_TEST_NAME")
+
+	c := runTest(t, testApplyInputSource{
+		Flag:       NewPathFlag(&cli.PathFlag{Name: "test", Expand: true}),
+		FlagName:   "test",
+		MapValue:   "$ALTSRC_TEST_DIR",
+		SourcePath: "/path/to/source/file",
+	})
+	expect(t, c.Path("test"), dir)
+
+	c = runTest(t, testApplyInputSource{
+		Flag:       NewPathSliceFlag(&cli.PathSliceFlag{Name: "test", Expand: true}),
+		FlagName:   "test",
+		MapValue:   []interface{}{"$ALTSRC_TEST_DIR", "$ALTSRC_TEST_NAME"},
+		SourcePath: "/path/to/source/file",
+	})
+
+	expected := []string{dir, "/path/to/source/expanded"}
+	if runtime.GOOS == "windows" {
+		expected = []string{dir, `D:\path\to\source\expanded`}
+	}
+	expect(t, c.PathSlice("test"), expected)
+}
+
+func TestStringMapApplyInputSourceMethodSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:     NewStringMapFlag(&cli.StringMapFlag{Name: "test", Aliases: []string{"t"}}),
+		FlagName: "test",
+		MapValue: map[interface{}]interface{}{"tier": "web,api", "replicas": 3},
+	})
+	expect(t, c.StringMap("test"), map[string]string{"tier": "web,api", "replicas": "3"})
+	expect(t, c.StringMap("t"), map[string]string{"tier": "web,api", "replicas": "3"})
+}
+
+func TestStringMapApplyInputSourceMethodContextSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:               NewStringMapFlag(&cli.StringMapFlag{Name: "test"}),
+		FlagName:           "test",
+		MapValue:           map[interface{}]interface{}{"tier": "web"},
+		ContextValueString: "tier=db",
+	})
+	expect(t, c.StringMap("test"), map[string]string{"tier": "db"})
+}
+
+func TestStringMapApplyInputSourceMethodJSON(t *testing.T) {
+	source, err := NewJSONSource([]byte(`{"test": {"tier": "web", "debug": true}, "bad": {"nested": {}}}`))
+	expect(t, err, nil)
+	isc, ok := source.(InputSourceStringMapper)
+	expect(t, ok, true)
+
+	m, err := isc.StringMap("test")
+	expect(t, err, nil)
+	expect(t, m, map[string]string{"tier": "web", "debug": "true"})
+
+	_, err = isc.StringMap("bad")
+	expect(t, err.Error(), "Mismatched type for flag 'bad.nested'. Expected 'string' but actual is ''")
+}
+
 func TestIntApplyInputSourceMethodSet(t *testing.T) {
 	c := runTest(t, testApplyInputSource{
 		Flag:     NewIntFlag(&cli.IntFlag{Name: "test"}),
@@ -262,6 +393,239 @@ func TestIntApplyInputSourceMethodEnvVarSet(t *testing.T) {
 	expect(t, 12, c.Int("test"))
 }
 
+func TestCountApplyInputSourceMethodSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:     NewCountFlag(&cli.CountFlag{Name: "test"}),
+		FlagName: "test",
+		MapValue: 3,
+	})
+	expect(t, 3, c.Count("test"))
+}
+
+func TestCountApplyInputSourceMethodAliases(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:     NewCountFlag(&cli.CountFlag{Name: "test", Aliases: []string{"t"}}),
+		FlagName: "test",
+		MapValue: "2",
+	})
+	expect(t, c.Count("test"), 2)
+	expect(t, c.Count("t"), 2)
+
+	c = runTest(t, testApplyInputSource{
+		Flag:     NewCountFlag(&cli.CountFlag{Name: "test", Value: 1}),
+		FlagName: "other",
+		MapValue: 3,
+	})
+	expect(t, c.Count("test"), 1)
+}
+
+func TestCountApplyInputSourceMethodInvalid(t *testing.T) {
+	fl := NewCountFlag(&cli.CountFlag{Name: "test"})
+	set := flag.NewFlagSet("test", 0)
+	_ = fl.Apply(set)
+
+	c := cli.NewContext(nil, set, nil)
+	err := fl.ApplyInputSourceValue(c, &MapInputSource{file: "c.yaml", valueMap: map[interface{}]interface{}{"test": -1}})
+	expect(t, err.Error(), "unable to apply test from c.yaml: count must not be negative: -1")
+}
+
+func TestCountApplyInputSourceMethodContextSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:               NewCountFlag(&cli.CountFlag{Name: "test"}),
+		FlagName:           "test",
+		MapValue:           3,
+		ContextValueString: "1",
+	})
+	expect(t, 1, c.Count("test"))
+}
+
+func TestByteSizeApplyInputSourceMethodSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:     NewByteSizeFlag(&cli.ByteSizeFlag{Name: "test"}),
+		FlagName: "test",
+		MapValue: "64MiB",
+	})
+	expect(t, c.ByteSize("test"), uint64(64<<20))
+
+	c = runTest(t, testApplyInputSource{
+		Flag:     NewByteSizeFlag(&cli.ByteSizeFlag{Name: "test"}),
+		FlagName: "test",
+		MapValue: 4096,
+	})
+	expect(t, c.ByteSize("test"), uint64(4096))
+}
+
+func TestByteSizeApplyInputSourceMethodContextSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:               NewByteSizeFlag(&cli.ByteSizeFlag{Name: "test"}),
+		FlagName:           "test",
+		MapValue:           "64MiB",
+		ContextValueString: "1KiB",
+	})
+	expect(t, c.ByteSize("test"), uint64(1024))
+}
+
+func TestIPApplyInputSourceMethodSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:     NewIPFlag(&cli.IPFlag{Name: "test"}),
+		FlagName: "test",
+		MapValue: "10.0.0.1",
+	})
+	expect(t, c.IP("test").String(), "10.0.0.1")
+}
+
+func TestIPApplyInputSourceMethodContextSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:               NewIPFlag(&cli.IPFlag{Name: "test"}),
+		FlagName:           "test",
+		MapValue:           "10.0.0.1",
+		ContextValueString: "::1",
+	})
+	expect(t, c.IP("test").String(), "::1")
+}
+
+func TestHostPortApplyInputSourceMethodInvalid(t *testing.T) {
+	fl := NewHostPortFlag(&cli.HostPortFlag{Name: "test"})
+	set := flag.NewFlagSet("test", 0)
+	_ = fl.Apply(set)
+
+	c := cli.NewContext(nil, set, nil)
+	err := fl.ApplyInputSourceValue(c, &MapInputSource{valueMap: map[interface{}]interface{}{"test": "localhost"}})
+	expect(t, err.Error(), `unable to apply test from : invalid host:port "localhost"`)
+}
+
+func TestOutputFileApplyInputSourceMethodSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:       NewOutputFileFlag(&cli.OutputFileFlag{Name: "test"}),
+		FlagName:   "test",
+		MapValue:   "out.txt",
+		SourcePath: "/path/to/source/file",
+	})
+
+	expected := "/path/to/source/out.txt"
+	if runtime.GOOS == "windows" {
+		expected = `D:\path\to\source\out.txt`
+	}
+	expect(t, c.Value("test"), expected)
+
+	c = runTest(t, testApplyInputSource{
+		Flag:       NewOutputFileFlag(&cli.OutputFileFlag{Name: "test"}),
+		FlagName:   "test",
+		MapValue:   "-",
+		SourcePath: "/path/to/source/file",
+	})
+	expect(t, c.Value("test"), "-")
+}
+
+func TestInputFileApplyInputSourceMethodInvalid(t *testing.T) {
+	fl := NewInputFileFlag(&cli.InputFileFlag{Name: "test"})
+	set := flag.NewFlagSet("test", 0)
+	_ = fl.Apply(set)
+
+	c := cli.NewContext(nil, set, nil)
+	err := fl.ApplyInputSourceValue(c, &MapInputSource{valueMap: map[interface{}]interface{}{"test": "/missing/in.txt"}})
+	expect(t, err.Error(), "unable to apply test from : stat /missing/in.txt: no such file or directory")
+}
+
+func TestURLSliceApplyInputSourceMethodSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:     NewURLSliceFlag(&cli.URLSliceFlag{Name: "test"}),
+		FlagName: "test",
+		MapValue: []interface{}{"https://example.com/a,b", "https://example.org"},
+	})
+	urls := c.URLSlice("test")
+	expect(t, len(urls), 2)
+	expect(t, urls[0].Path, "/a,b")
+	expect(t, urls[1].Host, "example.org")
+}
+
+func TestURLApplyInputSourceMethodSensitive(t *testing.T) {
+	fl := NewURLFlag(&cli.URLFlag{Name: "db", Sensitive: true})
+	set := flag.NewFlagSet("test", 0)
+	_ = fl.Apply(set)
+
+	c := cli.NewContext(nil, set, nil)
+	err := fl.ApplyInputSourceValue(c, &MapInputSource{file: "c.yaml", valueMap: map[interface{}]interface{}{"db": "https://db host/?token=SECRET"}})
+	expect(t, err.Error(), `unable to apply db from c.yaml: invalid URL "[REDACTED]": invalid character " " in host name`)
+
+	sl := NewURLSliceFlag(&cli.URLSliceFlag{Name: "mirrors", Sensitive: true})
+	set = flag.NewFlagSet("test", 0)
+	_ = sl.Apply(set)
+
+	c = cli.NewContext(nil, set, nil)
+	err = sl.ApplyInputSourceValue(c, &MapInputSource{file: "c.yaml", valueMap: map[interface{}]interface{}{"mirrors": []interface{}{"https://a.example.com", "https://bob:SECRET@[::1"}}})
+	expect(t, strings.Contains(err.Error(), "SECRET"), false)
+	expect(t, strings.Contains(err.Error(), "bob"), false)
+}
+
+func TestIPNetSliceApplyInputSourceMethodContextSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:               NewIPNetSliceFlag(&cli.IPNetSliceFlag{Name: "test"}),
+		FlagName:           "test",
+		MapValue:           []interface{}{"10.0.0.0/8"},
+		ContextValueString: "fd00::/8",
+	})
+	expect(t, fmt.Sprint(c.IPNetSlice("test")), "[fd00::/8]")
+}
+
+func TestUintSliceApplyInputSourceMethodSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:     NewUintSliceFlag(&cli.UintSliceFlag{Name: "test"}),
+		FlagName: "test",
+		MapValue: []interface{}{1, 2},
+	})
+	expect(t, c.UintSlice("test"), []uint{1, 2})
+}
+
+func TestUint64SliceApplyInputSourceMethodContextSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:               NewUint64SliceFlag(&cli.Uint64SliceFlag{Name: "test"}),
+		FlagName:           "test",
+		MapValue:           []interface{}{1, 2},
+		ContextValueString: "3",
+	})
+	expect(t, c.Uint64Slice("test"), []uint64{3})
+}
+
+func TestDurationSliceApplyInputSourceMethodSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:     NewDurationSliceFlag(&cli.DurationSliceFlag{Name: "test"}),
+		FlagName: "test",
+		MapValue: []interface{}{"1s", "1m"},
+	})
+	expect(t, c.DurationSlice("test"), []time.Duration{time.Second, time.Minute})
+}
+
+func TestTimestampSliceApplyInputSourceMethodSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:     NewTimestampSliceFlag(&cli.TimestampSliceFlag{Name: "test", Layout: "2006-01-02"}),
+		FlagName: "test",
+		MapValue: []interface{}{"2021-02-01", "2021-02-03"},
+	})
+	values := c.TimestampSlice("test")
+	expect(t, len(values), 2)
+	expect(t, values[1].Day(), 3)
+}
+
+func TestTimestampSliceApplyInputSourceMethodLayouts(t *testing.T) {
+	cet := time.FixedZone("CET", 3600)
+	c := runTest(t, testApplyInputSource{
+		Flag:     NewTimestampSliceFlag(&cli.TimestampSliceFlag{Name: "test", Layout: "2006-01-02", Layouts: []string{"02.01.2006 15:04"}, Timezone: cet}),
+		FlagName: "test",
+		MapValue: []interface{}{"03.02.2021 10:00", "@1612325106", "today"},
+	})
+	values := c.TimestampSlice("test")
+	expect(t, len(values), 3)
+	expect(t, values[0].Format(time.RFC3339), "2021-02-03T10:00:00+01:00")
+	expect(t, values[1].Format(time.RFC3339), "2021-02-03T05:05:06+01:00")
+
+	fl := NewTimestampSliceFlag(&cli.TimestampSliceFlag{Name: "test", Layout: "2006-01-02"})
+	set := flag.NewFlagSet("test", 0)
+	_ = fl.Apply(set)
+	err := fl.ApplyInputSourceValue(cli.NewContext(nil, set, nil), &MapInputSource{file: "c.yaml", valueMap: map[interface{}]interface{}{"test": []interface{}{"2020"}}})
+	expect(t, err.Error(), `unable to apply test from c.yaml: parsing time "2020" as "2006-01-02": cannot parse "" as "-"`)
+}
+
 func TestDurationApplyInputSourceMethodSet(t *testing.T) {
 	c := runTest(t, testApplyInputSource{
 		Flag:     NewDurationFlag(&cli.DurationFlag{Name: "test"}),
@@ -322,6 +686,208 @@ func TestFloat64ApplyInputSourceMethodEnvVarSet(t *testing.T) {
 	expect(t, 1.4, c.Float64("test"))
 }
 
+func TestOptionalBoolApplyInputSourceMethodSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:     NewOptionalBoolFlag(&cli.OptionalBoolFlag{Name: "test"}),
+		FlagName: "test",
+		MapValue: false,
+	})
+	no := false
+	expect(t, c.OptionalBool("test"), &no)
+
+	c = runTest(t, testApplyInputSource{
+		Flag:     NewOptionalBoolFlag(&cli.OptionalBoolFlag{Name: "test"}),
+		FlagName: "other",
+		MapValue: false,
+	})
+	expect(t, c.OptionalBool("test"), (*bool)(nil))
+}
+
+func TestLenientBoolApplyInputSourceMethodSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:     NewBoolFlag(&cli.BoolFlag{Name: "test", Lenient: true}),
+		FlagName: "test",
+		MapValue: "on",
+	})
+	expect(t, c.Bool("test"), true)
+
+	c = runTest(t, testApplyInputSource{
+		Flag:     NewBoolFlag(&cli.BoolFlag{Name: "test", Lenient: true}),
+		FlagName: "test",
+		MapValue: 1,
+	})
+	expect(t, c.Bool("test"), true)
+
+	no := false
+	c = runTest(t, testApplyInputSource{
+		Flag:     NewOptionalBoolFlag(&cli.OptionalBoolFlag{Name: "test", Lenient: true}),
+		FlagName: "test",
+		MapValue: "No",
+	})
+	expect(t, c.OptionalBool("test"), &no)
+}
+
+func TestAppLenientBoolsApplyInputSourceMethod(t *testing.T) {
+	fl := NewBoolFlag(&cli.BoolFlag{Name: "test"})
+	set := flag.NewFlagSet("test", 0)
+	_ = fl.Apply(set)
+
+	isc := &MapInputSource{file: "app.yaml", valueMap: map[interface{}]interface{}{"test": "on"}}
+	err := fl.ApplyInputSourceValue(cli.NewContext(&cli.App{}, set, nil), isc)
+	expect(t, err.Error(), "Mismatched type for flag 'test'. Expected 'bool' but actual is 'string'")
+
+	var value bool
+	flags := []cli.Flag{NewBoolFlag(&cli.BoolFlag{Name: "test"})}
+	app := &cli.App{
+		LenientBools: true,
+		Flags:        flags,
+		Before: InitInputSource(flags, func() (InputSourceContext, error) {
+			return isc, nil
+		}),
+		Action: func(ctx *cli.Context) error {
+			value = ctx.Bool("test")
+			return nil
+		},
+	}
+	expect(t, app.Run([]string{"run"}), nil)
+	expect(t, value, true)
+
+	// the wrapped flag is lenient on the command line too, for this app only
+	expect(t, app.Run([]string{"run", "--test=off"}), nil)
+	expect(t, value, false)
+
+	set = flag.NewFlagSet("test", flag.ContinueOnError)
+	set.SetOutput(ioutil.Discard)
+	_ = flags[0].Apply(set)
+	expect(t, set.Parse([]string{"--test=off"}) != nil, true)
+}
+
+func TestLenientBoolApplyInputSourceMethodInvalid(t *testing.T) {
+	fl := NewBoolFlag(&cli.BoolFlag{Name: "test", Lenient: true})
+	set := flag.NewFlagSet("test", 0)
+	_ = fl.Apply(set)
+
+	c := cli.NewContext(nil, set, nil)
+	err := fl.ApplyInputSourceValue(c, &MapInputSource{file: "app.yaml", valueMap: map[interface{}]interface{}{"test": "sometimes"}})
+	expect(t, strings.HasPrefix(err.Error(), `unable to apply test from app.yaml: invalid boolean "sometimes", expected one of`), true)
+}
+
+func TestOptionalBoolApplyInputSourceMethodContextSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:               NewOptionalBoolFlag(&cli.OptionalBoolFlag{Name: "test"}),
+		FlagName:           "test",
+		MapValue:           false,
+		ContextValueString: "true",
+	})
+	yes := true
+	expect(t, c.OptionalBool("test"), &yes)
+}
+
+func TestApplyInputSourceConfigKey(t *testing.T) {
+	inputSource := &MapInputSource{valueMap: map[interface{}]interface{}{
+		"test":   "flag name",
+		"server": map[interface{}]interface{}{"host": "config key"},
+	}}
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	c := cli.NewContext(nil, set, nil)
+
+	f := NewStringFlag(&cli.StringFlag{Name: "test"})
+	f.ConfigKey = "server.host"
+	_ = f.Apply(set)
+
+	expect(t, f.ApplyInputSourceValue(c, inputSource), nil)
+	expect(t, c.String("test"), "config key")
+}
+
+func TestApplyInputSourceConfigAliases(t *testing.T) {
+	inputSource := &MapInputSource{
+		file:     "config.yaml",
+		valueMap: map[interface{}]interface{}{"legacy": 15},
+	}
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	c := cli.NewContext(nil, set, nil)
+
+	var w strings.Builder
+	defer func(old io.Writer) { cli.ErrWriter = old }(cli.ErrWriter)
+	cli.ErrWriter = &w
+
+	f := &IntFlag{
+		IntFlag:    &cli.IntFlag{Name: "test"},
+		ConfigKeys: ConfigKeys{ConfigAliases: []string{"older", "legacy"}},
+	}
+	_ = f.Apply(set)
+
+	expect(t, f.ApplyInputSourceValue(c, inputSource), nil)
+	expect(t, c.Int("test"), 15)
+	expect(t, w.String(), "Deprecated config key 'legacy' in 'config.yaml', use 'test' instead\n")
+}
+
+func TestApplyInputSourceConfigKeyWinsOverAliases(t *testing.T) {
+	inputSource := &MapInputSource{valueMap: map[interface{}]interface{}{
+		"test":   "current",
+		"legacy": "old",
+	}}
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	c := cli.NewContext(nil, set, nil)
+
+	var w strings.Builder
+	defer func(old io.Writer) { cli.ErrWriter = old }(cli.ErrWriter)
+	cli.ErrWriter = &w
+
+	f := NewStringFlag(&cli.StringFlag{Name: "test"})
+	f.ConfigAliases = []string{"legacy"}
+	_ = f.Apply(set)
+
+	expect(t, f.ApplyInputSourceValue(c, inputSource), nil)
+	expect(t, c.String("test"), "current")
+	expect(t, w.String(), "")
+}
+
+// legacyInputSource is an input source implemented without IsSet and
+// StringMap
+type legacyInputSource struct {
+	InputSourceContext
+}
+
+func TestApplyInputSourceWithoutKeyChecker(t *testing.T) {
+	inputSource := legacyInputSource{&MapInputSource{valueMap: map[interface{}]interface{}{
+		"legacy":  "old",
+		"enabled": true,
+		"cache":   false,
+		"labels":  map[interface{}]interface{}{"env": "prod"},
+	}}}
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	c := cli.NewContext(nil, set, nil)
+
+	f := NewStringFlag(&cli.StringFlag{Name: "test", Value: "default"})
+	f.ConfigAliases = []string{"legacy"}
+	enabled := NewOptionalBoolFlag(&cli.OptionalBoolFlag{Name: "enabled"})
+	cache := NewOptionalBoolFlag(&cli.OptionalBoolFlag{Name: "cache"})
+	labels := NewStringMapFlag(&cli.StringMapFlag{Name: "labels"})
+	for _, fl := range []FlagInputSourceExtension{f, enabled, cache, labels} {
+		_ = fl.Apply(set)
+		expect(t, fl.ApplyInputSourceValue(c, inputSource), nil)
+	}
+
+	yes := true
+	expect(t, c.String("test"), "default")
+	expect(t, c.OptionalBool("enabled"), &yes)
+	expect(t, c.OptionalBool("cache"), (*bool)(nil))
+	expect(t, len(c.StringMap("labels")), 0)
+}
+
+func TestApplyInputSourceSkipConfig(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag: &StringFlag{
+			StringFlag: &cli.StringFlag{Name: "test", Value: "default"},
+			ConfigKeys: ConfigKeys{SkipConfig: true},
+		},
+		FlagName: "test",
+		MapValue: "hello",
+	})
+	expect(t, c.String("test"), "default")
+}
+
 func runTest(t *testing.T, test testApplyInputSource) *cli.Context {
 	inputSource := &MapInputSource{
 		file:     test.SourcePath,
@@ -37,3 +37,36 @@ type InputSourceContext interface {
 	Generic(name string) (cli.Generic, error)
 	Bool(name string) (bool, error)
 }
+
+// InputSourceKeyChecker is implemented by the input sources able to report
+// whether they hold a value for a key. Config aliases and unset optional
+// bools need it, other input sources are read without them.
+type InputSourceKeyChecker interface {
+	IsSet(name string) bool
+}
+
+// isSet reports whether isc holds a value for name, and whether it can tell
+func isSet(isc InputSourceContext, name string) (set bool, ok bool) {
+	checker, ok := isc.(InputSourceKeyChecker)
+	if !ok {
+		return false, false
+	}
+	return checker.IsSet(name), true
+}
+
+// InputSourceStringMapper is implemented by the input sources able to read
+// maps of strings. StringMapFlag and GenericMapFlag need it, other input
+// sources leave them unset.
+type InputSourceStringMapper interface {
+	StringMap(name string) (map[string]string, error)
+}
+
+// stringMap reads the map of strings of isc at name, nil if isc can't read
+// maps
+func stringMap(isc InputSourceContext, name string) (map[string]string, error) {
+	mapper, ok := isc.(InputSourceStringMapper)
+	if !ok {
+		return nil, nil
+	}
+	return mapper.StringMap(name)
+}
@@ -0,0 +1,131 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package altsrc
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+)
+
+// interpolator expands `${name}` references in string values of an input
+// source. A reference names either another key of the input source, which
+// takes precedence, or an environment variable. `${name:-default}` falls
+// back to default when the key is missing and the environment variable is
+// unset or empty. `$${` produces a literal `${`.
+type interpolator struct {
+	lookup    func(name string) (interface{}, bool)
+	resolving []string
+}
+
+// interpolate expands the references in value, which was read from key.
+func interpolate(key, value string, lookup func(name string) (interface{}, bool)) (string, error) {
+	if !strings.Contains(value, "${") {
+		return value, nil
+	}
+	ip := &interpolator{lookup: lookup, resolving: []string{key}}
+	return ip.expand(key, value)
+}
+
+func (ip *interpolator) expand(key, value string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(value); {
+		switch {
+		case strings.HasPrefix(value[i:], "$${"):
+			b.WriteString("${")
+			i += 3
+		case strings.HasPrefix(value[i:], "${"):
+			end := closingBrace(value, i+2)
+			if end < 0 {
+				return "", fmt.Errorf("Unable to interpolate '%s': unterminated '${' in %q", key, value)
+			}
+			expanded, err := ip.reference(key, value[i+2:end])
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(expanded)
+			i = end + 1
+		default:
+			b.WriteByte(value[i])
+			i++
+		}
+	}
+	return b.String(), nil
+}
+
+// reference resolves the body of a single `${...}` reference.
+func (ip *interpolator) reference(key, ref string) (string, error) {
+	name, def, hasDefault := ref, "", false
+	if idx := strings.Index(ref, ":-"); idx >= 0 {
+		name, def, hasDefault = ref[:idx], ref[idx+2:], true
+	}
+	if name == "" {
+		return "", fmt.Errorf("Unable to interpolate '%s': empty reference '${%s}'", key, ref)
+	}
+
+	if val, ok := ip.lookup(name); ok {
+		return ip.resolve(key, name, val)
+	}
+
+	if env, ok := syscall.Getenv(name); ok && (env != "" || !hasDefault) {
+		return env, nil
+	}
+
+	if hasDefault {
+		return ip.expand(key, def)
+	}
+	return "", nil
+}
+
+// resolve converts the value of the referenced key to a string, expanding
+// the references it contains in turn.
+func (ip *interpolator) resolve(key, name string, val interface{}) (string, error) {
+	for _, resolving := range ip.resolving {
+		if resolving == name {
+			cycle := strings.Join(append(ip.resolving, name), " -> ")
+			return "", fmt.Errorf("Unable to interpolate '%s': reference cycle %s", key, cycle)
+		}
+	}
+
+	switch v := val.(type) {
+	case string:
+		ip.resolving = append(ip.resolving, name)
+		defer func() { ip.resolving = ip.resolving[:len(ip.resolving)-1] }()
+		return ip.expand(key, v)
+	case bool, int, int64, uint64, float64:
+		return fmt.Sprint(v), nil
+	default:
+		return "", fmt.Errorf("Unable to interpolate '%s': '%s' is not a scalar value but %T", key, name, val)
+	}
+}
+
+// closingBrace returns the index of the '}' closing the reference whose body
+// starts at start, allowing nested references in defaults.
+func closingBrace(value string, start int) int {
+	depth := 0
+	for i := start; i < len(value); i++ {
+		switch {
+		case strings.HasPrefix(value[i:], "${"):
+			depth++
+			i++
+		case value[i] == '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
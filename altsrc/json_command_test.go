@@ -15,6 +15,7 @@
 package altsrc
 
 import (
+	"errors"
 	"flag"
 	"io/ioutil"
 	"os"
@@ -326,6 +327,38 @@ func TestCommandJSONFileFlagHasDefaultGlobalEnvJSONSetGlobalEnvWinsNested(t *tes
 	expect(t, err, nil)
 }
 
+func TestJSONSourceListPaths(t *testing.T) {
+	source, err := NewJSONSource([]byte(`{"servers": [{"host": "a"}, {"host": "b"}, {"port": 1}]}`))
+	expect(t, err, nil)
+
+	host, err := source.String("servers[0].host")
+	expect(t, err, nil)
+	expect(t, host, "a")
+
+	hosts, err := source.StringSlice("servers[*].host")
+	expect(t, err, nil)
+	expect(t, hosts, []string{"a", "b"})
+
+	_, err = source.String("servers[3].host")
+	expect(t, err, errors.New(`missing key "servers[3].host"`))
+}
+
+func TestJSONSourceInterpolation(t *testing.T) {
+	source, err := NewJSONSource([]byte(`{"host": "example.com", "port": 80, "url": "http://${host}:${port}", "hosts": ["${host}"], "a": "${b}", "b": "${a}"}`))
+	expect(t, err, nil)
+
+	url, err := source.String("url")
+	expect(t, err, nil)
+	expect(t, url, "http://example.com:80")
+
+	hosts, err := source.StringSlice("hosts")
+	expect(t, err, nil)
+	expect(t, hosts, []string{"example.com"})
+
+	_, err = source.String("a")
+	expect(t, err, errors.New("Unable to interpolate 'a': reference cycle a -> b -> a"))
+}
+
 func writeTempFile(t *testing.T, name string, content string) func() {
 	if err := ioutil.WriteFile(name, []byte(content), 0666); err != nil {
 		t.Fatalf("cannot write %q: %v", name, err)
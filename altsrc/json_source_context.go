@@ -19,7 +19,6 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"strings"
 	"time"
 
 	"github.com/vine-io/cli"
@@ -71,21 +70,17 @@ func (x *jsonSource) Source() string {
 	return x.file
 }
 
+func (x *jsonSource) IsSet(name string) bool {
+	_, err := x.getValue(name)
+	return err == nil
+}
+
 func (x *jsonSource) Int(name string) (int, error) {
 	i, err := x.getValue(name)
 	if err != nil {
 		return 0, err
 	}
-	switch v := i.(type) {
-	default:
-		return 0, fmt.Errorf("unexpected type %T for %q", i, name)
-	case int:
-		return v, nil
-	case float32:
-		return int(v), nil
-	case float64:
-		return int(v), nil
-	}
+	return castInt(name, i)
 }
 
 func (x *jsonSource) Duration(name string) (time.Duration, error) {
@@ -121,7 +116,7 @@ func (x *jsonSource) String(name string) (string, error) {
 	if !ok {
 		return "", fmt.Errorf("unexpected type %T for %q", i, name)
 	}
-	return v, nil
+	return interpolate(name, v, x.lookup)
 }
 
 func (x *jsonSource) StringSlice(name string) ([]string, error) {
@@ -133,11 +128,23 @@ func (x *jsonSource) StringSlice(name string) ([]string, error) {
 	default:
 		return nil, fmt.Errorf("unexpected type %T for %q", i, name)
 	case []string:
-		return v, nil
+		c := make([]string, 0, len(v))
+		for ix, s := range v {
+			str, err := interpolate(fmt.Sprintf("%s[%d]", name, ix), s, x.lookup)
+			if err != nil {
+				return nil, err
+			}
+			c = append(c, str)
+		}
+		return c, nil
 	case []interface{}:
 		c := []string{}
-		for _, s := range v {
+		for ix, s := range v {
 			if str, ok := s.(string); ok {
+				str, err := interpolate(fmt.Sprintf("%s[%d]", name, ix), str, x.lookup)
+				if err != nil {
+					return nil, err
+				}
 				c = append(c, str)
 			} else {
 				return c, fmt.Errorf("unexpected item type %T in %T for %q", s, c, name)
@@ -170,6 +177,16 @@ func (x *jsonSource) IntSlice(name string) ([]int, error) {
 	}
 }
 
+func (x *jsonSource) StringMap(name string) (map[string]string, error) {
+	i, err := x.getValue(name)
+	if err != nil {
+		return nil, err
+	}
+	return castStringMap(name, i, func(key, value string) (string, error) {
+		return interpolate(key, value, x.lookup)
+	})
+}
+
 func (x *jsonSource) Generic(name string) (cli.Generic, error) {
 	i, err := x.getValue(name)
 	if err != nil {
@@ -198,22 +215,13 @@ func (x *jsonSource) getValue(key string) (interface{}, error) {
 	return jsonGetValue(key, x.deserialized)
 }
 
+func (x *jsonSource) lookup(key string) (interface{}, bool) {
+	i, err := x.getValue(key)
+	return i, err == nil
+}
+
 func jsonGetValue(key string, m map[string]interface{}) (interface{}, error) {
-	var ret interface{}
-	var ok bool
-	working := m
-	keys := strings.Split(key, ".")
-	for ix, k := range keys {
-		if ret, ok = working[k]; !ok {
-			return ret, fmt.Errorf("missing key %q", key)
-		}
-		if working, ok = ret.(map[string]interface{}); !ok {
-			if ix < len(keys)-1 {
-				return ret, fmt.Errorf("unexpected intermediate value at %q segment of %q: %T", k, key, ret)
-			}
-		}
-	}
-	return ret, nil
+	return lookupKeyPath(m, key)
 }
 
 type jsonSource struct {
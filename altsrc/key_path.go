@@ -0,0 +1,208 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package altsrc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// keySegment is a single step of a key path: a map key, a list index or a
+// wildcard matching every element of a list.
+type keySegment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+func (s keySegment) String() string {
+	switch {
+	case s.wildcard:
+		return "[*]"
+	case s.isIndex:
+		return fmt.Sprintf("[%d]", s.index)
+	}
+	return s.key
+}
+
+// parseKeyPath splits a key path into its segments. Segments are separated
+// by '.', list elements are addressed with `[0]` or `[*]`, and keys that
+// contain dots or brackets can be quoted, e.g. `"example.com".port` or
+// `hosts["example.com"]`.
+func parseKeyPath(path string) ([]keySegment, error) {
+	var segments []keySegment
+	expectKey := true
+
+	for i := 0; i < len(path); {
+		switch c := path[i]; {
+		case c == '.':
+			if expectKey {
+				return nil, fmt.Errorf("empty segment at offset %d", i)
+			}
+			expectKey = true
+			i++
+		case c == '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated '[' at offset %d", i)
+			}
+			inner := path[i+1 : i+end]
+			switch {
+			case inner == "*":
+				segments = append(segments, keySegment{wildcard: true})
+				i += end + 1
+			case len(inner) > 0 && (inner[0] == '"' || inner[0] == '\''):
+				key, n, err := unquoteKey(path[i+1:])
+				if err != nil {
+					return nil, err
+				}
+				if i+1+n >= len(path) || path[i+1+n] != ']' {
+					return nil, fmt.Errorf("expected ']' at offset %d", i+1+n)
+				}
+				segments = append(segments, keySegment{key: key})
+				i += n + 2
+			default:
+				index, err := strconv.Atoi(inner)
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("invalid list index %q", inner)
+				}
+				segments = append(segments, keySegment{index: index, isIndex: true})
+				i += end + 1
+			}
+			expectKey = false
+		case c == '"' || c == '\'':
+			if !expectKey {
+				return nil, fmt.Errorf("unexpected quote at offset %d", i)
+			}
+			key, n, err := unquoteKey(path[i:])
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, keySegment{key: key})
+			expectKey = false
+			i += n
+		default:
+			if !expectKey {
+				return nil, fmt.Errorf("expected '.' or '[' at offset %d", i)
+			}
+			end := strings.IndexAny(path[i:], ".[")
+			if end < 0 {
+				end = len(path) - i
+			}
+			segments = append(segments, keySegment{key: path[i : i+end]})
+			expectKey = false
+			i += end
+		}
+	}
+
+	if expectKey {
+		return nil, fmt.Errorf("key path %q must not be empty or end with '.'", path)
+	}
+	return segments, nil
+}
+
+// unquoteKey reads a quoted key from the start of s and returns it along with
+// the number of bytes consumed. A backslash escapes the following byte.
+func unquoteKey(s string) (string, int, error) {
+	quote := s[0]
+	var key strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				key.WriteByte(s[i])
+			}
+		case quote:
+			return key.String(), i + 1, nil
+		default:
+			key.WriteByte(s[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated quoted key %s", s)
+}
+
+// lookupKeyPath follows the key path through nested maps and lists starting
+// at tree. A wildcard collects the values found in every element of a list
+// into a []interface{}.
+func lookupKeyPath(tree interface{}, path string) (interface{}, error) {
+	segments, err := parseKeyPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key %q: %v", path, err)
+	}
+	return walkKeyPath(tree, segments, path)
+}
+
+func walkKeyPath(node interface{}, segments []keySegment, path string) (interface{}, error) {
+	for i, segment := range segments {
+		if segment.wildcard {
+			list, ok := node.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("unexpected intermediate value at %q segment of %q: %T", segment, path, node)
+			}
+			collected := make([]interface{}, 0, len(list))
+			for _, element := range list {
+				value, err := walkKeyPath(element, segments[i+1:], path)
+				if err != nil {
+					continue
+				}
+				if nested, ok := value.([]interface{}); ok && hasWildcard(segments[i+1:]) {
+					collected = append(collected, nested...)
+					continue
+				}
+				collected = append(collected, value)
+			}
+			return collected, nil
+		}
+
+		var ok bool
+		switch n := node.(type) {
+		case map[interface{}]interface{}:
+			if segment.isIndex {
+				return nil, fmt.Errorf("unexpected intermediate value at %q segment of %q: %T", segment, path, node)
+			}
+			node, ok = n[segment.key]
+		case map[string]interface{}:
+			if segment.isIndex {
+				return nil, fmt.Errorf("unexpected intermediate value at %q segment of %q: %T", segment, path, node)
+			}
+			node, ok = n[segment.key]
+		case []interface{}:
+			if !segment.isIndex {
+				return nil, fmt.Errorf("unexpected intermediate value at %q segment of %q: %T", segment, path, node)
+			}
+			if ok = segment.index < len(n); ok {
+				node = n[segment.index]
+			}
+		default:
+			return nil, fmt.Errorf("unexpected intermediate value at %q segment of %q: %T", segment, path, node)
+		}
+		if !ok {
+			return nil, fmt.Errorf("missing key %q", path)
+		}
+	}
+	return node, nil
+}
+
+func hasWildcard(segments []keySegment) bool {
+	for _, segment := range segments {
+		if segment.wildcard {
+			return true
+		}
+	}
+	return false
+}
@@ -16,7 +16,9 @@ package altsrc
 
 import (
 	"fmt"
+	"math"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -30,28 +32,18 @@ type MapInputSource struct {
 	valueMap map[interface{}]interface{}
 }
 
-// nestedVal checks if the name has '.' delimiters.
-// If so, it tries to traverse the tree by the '.' delimited sections to find
-// a nested value for the key.
+// nestedVal checks if the name is a key path such as `a.b`, `list[0].c` or
+// `list[*].c`. If so, it tries to traverse the tree by the path segments to
+// find a nested value for the key.
 func nestedVal(name string, tree map[interface{}]interface{}) (interface{}, bool) {
-	if sections := strings.Split(name, "."); len(sections) > 1 {
-		node := tree
-		for _, section := range sections[:len(sections)-1] {
-			child, ok := node[section]
-			if !ok {
-				return nil, false
-			}
-			ctype, ok := child.(map[interface{}]interface{})
-			if !ok {
-				return nil, false
-			}
-			node = ctype
-		}
-		if val, ok := node[sections[len(sections)-1]]; ok {
-			return val, true
-		}
+	if !strings.ContainsAny(name, ".[\"'") {
+		return nil, false
 	}
-	return nil, false
+	val, err := lookupKeyPath(tree, name)
+	if err != nil {
+		return nil, false
+	}
+	return val, true
 }
 
 // Source returns the path of the source file
@@ -59,40 +51,86 @@ func (fsm *MapInputSource) Source() string {
 	return fsm.file
 }
 
-// Int returns an int from the map if it exists otherwise returns 0
+// IsSet returns true if the map holds a value for the given key
+func (fsm *MapInputSource) IsSet(name string) bool {
+	_, exists := fsm.lookup(name)
+	return exists
+}
+
+func (fsm *MapInputSource) lookup(name string) (interface{}, bool) {
+	if val, exists := fsm.valueMap[name]; exists {
+		return val, true
+	}
+	return nestedVal(name, fsm.valueMap)
+}
+
+// interpolate expands the ${...} references in a string value read from name
+func (fsm *MapInputSource) interpolate(name, value string) (string, error) {
+	return interpolate(name, value, fsm.lookup)
+}
+
+// Int returns an int from the map if it exists otherwise returns 0. Any
+// integer type, integral floats and numeric strings such as 0x1F are
+// accepted as long as they fit in an int.
 func (fsm *MapInputSource) Int(name string) (int, error) {
-	otherGenericValue, exists := fsm.valueMap[name]
-	if exists {
-		otherValue, isType := otherGenericValue.(int)
-		if !isType {
-			return 0, incorrectTypeForFlagError(name, "int", otherGenericValue)
-		}
-		return otherValue, nil
+	otherGenericValue, exists := fsm.lookup(name)
+	if !exists {
+		return 0, nil
 	}
-	nestedGenericValue, exists := nestedVal(name, fsm.valueMap)
-	if exists {
-		otherValue, isType := nestedGenericValue.(int)
-		if !isType {
-			return 0, incorrectTypeForFlagError(name, "int", nestedGenericValue)
+	return castInt(name, otherGenericValue)
+}
+
+const (
+	maxInt = int64(^uint(0) >> 1)
+	minInt = -maxInt - 1
+)
+
+// castInt converts the integer, float or string value of the flag name to
+// an int, reporting values that are not integers or do not fit
+func castInt(name string, value interface{}) (int, error) {
+	if s, isType := value.(string); isType {
+		n, err := strconv.ParseInt(strings.TrimSpace(s), 0, strconv.IntSize)
+		if err != nil {
+			return 0, incorrectTypeForFlagError(name, "int", value)
 		}
-		return otherValue, nil
+		return int(n), nil
 	}
 
-	return 0, nil
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n := v.Int(); n >= minInt && n <= maxInt {
+			return int(n), nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if n := v.Uint(); n <= uint64(maxInt) {
+			return int(n), nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if f := v.Float(); f == math.Trunc(f) && f >= float64(minInt) && f < -float64(minInt) {
+			return int(f), nil
+		}
+	default:
+		return 0, incorrectTypeForFlagError(name, "int", value)
+	}
+	return 0, fmt.Errorf("value %v of flag '%s' is out of range for a %d-bit int", value, name, strconv.IntSize)
 }
 
 // Duration returns a duration from the map if it exists otherwise returns 0
 func (fsm *MapInputSource) Duration(name string) (time.Duration, error) {
-	otherGenericValue, exists := fsm.valueMap[name]
-	if exists {
-		return castDuration(name, otherGenericValue)
+	otherGenericValue, exists := fsm.lookup(name)
+	if !exists {
+		return 0, nil
 	}
-	nestedGenericValue, exists := nestedVal(name, fsm.valueMap)
-	if exists {
-		return castDuration(name, nestedGenericValue)
+	if otherStringValue, isType := otherGenericValue.(string); isType {
+		expanded, err := fsm.interpolate(name, otherStringValue)
+		if err != nil {
+			return 0, err
+		}
+		otherGenericValue = expanded
 	}
 
-	return 0, nil
+	return castDuration(name, otherGenericValue)
 }
 
 func castDuration(name string, value interface{}) (time.Duration, error) {
@@ -137,7 +175,7 @@ func (fsm *MapInputSource) String(name string) (string, error) {
 		if !isType {
 			return "", incorrectTypeForFlagError(name, "string", otherGenericValue)
 		}
-		return otherValue, nil
+		return fsm.interpolate(name, otherValue)
 	}
 	nestedGenericValue, exists := nestedVal(name, fsm.valueMap)
 	if exists {
@@ -145,7 +183,7 @@ func (fsm *MapInputSource) String(name string) (string, error) {
 		if !isType {
 			return "", incorrectTypeForFlagError(name, "string", nestedGenericValue)
 		}
-		return otherValue, nil
+		return fsm.interpolate(name, otherValue)
 	}
 
 	return "", nil
@@ -174,6 +212,11 @@ func (fsm *MapInputSource) StringSlice(name string) ([]string, error) {
 			return nil, incorrectTypeForFlagError(fmt.Sprintf("%s[%d]", name, i), "string", v)
 		}
 
+		stringValue, err := fsm.interpolate(fmt.Sprintf("%s[%d]", name, i), stringValue)
+		if err != nil {
+			return nil, err
+		}
+
 		stringSlice = append(stringSlice, stringValue)
 	}
 
@@ -209,6 +252,51 @@ func (fsm *MapInputSource) IntSlice(name string) ([]int, error) {
 	return intSlice, nil
 }
 
+// StringMap returns a map[string]string from the map if it exists otherwise
+// returns nil. Scalar values are formatted as strings.
+func (fsm *MapInputSource) StringMap(name string) (map[string]string, error) {
+	otherGenericValue, exists := fsm.lookup(name)
+	if !exists {
+		return nil, nil
+	}
+
+	return castStringMap(name, otherGenericValue, fsm.interpolate)
+}
+
+// castStringMap converts a map read from a source to a map[string]string
+func castStringMap(name string, value interface{}, interpolate func(name, value string) (string, error)) (map[string]string, error) {
+	values := map[string]interface{}{}
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		for k, val := range v {
+			values[fmt.Sprint(k)] = val
+		}
+	case map[string]interface{}:
+		values = v
+	default:
+		return nil, incorrectTypeForFlagError(name, "map", value)
+	}
+
+	stringMap := make(map[string]string, len(values))
+	for k, v := range values {
+		key := fmt.Sprintf("%s.%s", name, k)
+		switch v.(type) {
+		case map[interface{}]interface{}, map[string]interface{}, []interface{}, nil:
+			return nil, incorrectTypeForFlagError(key, "string", v)
+		case string:
+			s, err := interpolate(key, v.(string))
+			if err != nil {
+				return nil, err
+			}
+			stringMap[k] = s
+		default:
+			stringMap[k] = fmt.Sprint(v)
+		}
+	}
+
+	return stringMap, nil
+}
+
 // Generic returns an cli.Generic from the map if it exists otherwise returns nil
 func (fsm *MapInputSource) Generic(name string) (cli.Generic, error) {
 	otherGenericValue, exists := fsm.valueMap[name]
@@ -15,6 +15,8 @@
 package altsrc
 
 import (
+	"errors"
+	"os"
 	"testing"
 	"time"
 )
@@ -37,3 +39,156 @@ func TestMapDuration(t *testing.T) {
 	_, err = inputSource.Duration("duration_of_int_type")
 	refute(t, nil, err)
 }
+
+func TestMapInt(t *testing.T) {
+	inputSource := &MapInputSource{
+		file: "test",
+		valueMap: map[interface{}]interface{}{
+			"int64":    int64(42),
+			"uint64":   uint64(7),
+			"float":    float64(3),
+			"fraction": 1.5,
+			"hex":      "0x1F",
+			"huge":     uint64(18446744073709551615),
+			"word":     "many",
+		},
+	}
+	for key, expected := range map[string]int{"int64": 42, "uint64": 7, "float": 3, "hex": 31} {
+		n, err := inputSource.Int(key)
+		expect(t, err, nil)
+		expect(t, n, expected)
+	}
+	for _, key := range []string{"fraction", "huge", "word"} {
+		_, err := inputSource.Int(key)
+		refute(t, nil, err)
+	}
+}
+
+func TestMapIsSet(t *testing.T) {
+	inputSource := &MapInputSource{
+		file: "test",
+		valueMap: map[interface{}]interface{}{
+			"top": map[interface{}]interface{}{"nested": 1},
+			"key": "value",
+		},
+	}
+	expect(t, inputSource.IsSet("key"), true)
+	expect(t, inputSource.IsSet("top.nested"), true)
+	expect(t, inputSource.IsSet("top.missing"), false)
+	expect(t, inputSource.IsSet("missing"), false)
+}
+
+func TestMapListPaths(t *testing.T) {
+	inputSource := &MapInputSource{
+		file: "test",
+		valueMap: map[interface{}]interface{}{
+			"servers": []interface{}{
+				map[interface{}]interface{}{"host": "a.example.com", "port": 80},
+				map[interface{}]interface{}{"host": "b.example.com", "port": 8080},
+			},
+			"zones": map[interface{}]interface{}{
+				"example.com": map[interface{}]interface{}{"ttl": "1m"},
+			},
+		},
+	}
+
+	host, err := inputSource.String("servers[1].host")
+	expect(t, err, nil)
+	expect(t, host, "b.example.com")
+
+	port, err := inputSource.Int("servers[0].port")
+	expect(t, err, nil)
+	expect(t, port, 80)
+
+	hosts, err := inputSource.StringSlice("servers[*].host")
+	expect(t, err, nil)
+	expect(t, hosts, []string{"a.example.com", "b.example.com"})
+
+	ports, err := inputSource.IntSlice("servers[*].port")
+	expect(t, err, nil)
+	expect(t, ports, []int{80, 8080})
+
+	ttl, err := inputSource.Duration(`zones."example.com".ttl`)
+	expect(t, err, nil)
+	expect(t, ttl, time.Minute)
+
+	ttl, err = inputSource.Duration(`zones["example.com"].ttl`)
+	expect(t, err, nil)
+	expect(t, ttl, time.Minute)
+
+	expect(t, inputSource.IsSet("servers[2].host"), false)
+	expect(t, inputSource.IsSet("servers.host"), false)
+}
+
+func TestParseKeyPath(t *testing.T) {
+	segments, err := parseKeyPath(`a."b.c"[3]['d]'][*].e`)
+	expect(t, err, nil)
+	expect(t, segments, []keySegment{
+		{key: "a"},
+		{key: "b.c"},
+		{index: 3, isIndex: true},
+		{key: "d]"},
+		{wildcard: true},
+		{key: "e"},
+	})
+
+	for _, path := range []string{"", "a.", ".a", "a..b", "a[", "a[x]", "a[-1]", `a."b`, "a[0]b"} {
+		if _, err := parseKeyPath(path); err == nil {
+			t.Errorf("expected an error for key path %q", path)
+		}
+	}
+}
+
+func TestMapInterpolation(t *testing.T) {
+	_ = os.Setenv("ALTSRC_TEST_HOST", "env.example.com")
+	defer os.Unsetenv("ALTSRC_TEST_HOST")
+	_ = os.Setenv("ALTSRC_TEST_EMPTY", "")
+	defer os.Unsetenv("ALTSRC_TEST_EMPTY")
+
+	inputSource := &MapInputSource{
+		file: "test",
+		valueMap: map[interface{}]interface{}{
+			"server": map[interface{}]interface{}{
+				"host": "${ALTSRC_TEST_HOST}",
+				"port": 8080,
+			},
+			"url":      "http://${server.host}:${server.port}/",
+			"fallback": "${ALTSRC_TEST_EMPTY:-${ALTSRC_TEST_UNSET:-default}}",
+			"literal":  "$${server.host}",
+			"timeout":  "${ALTSRC_TEST_TIMEOUT:-5s}",
+			"list":     []interface{}{"${server.host}", "plain"},
+			"self":     "${loop.a}",
+			"loop":     map[interface{}]interface{}{"a": "${loop.b}", "b": "x${loop.a}"},
+			"nested":   "${server}",
+			"broken":   "${server.host",
+		},
+	}
+
+	for key, expected := range map[string]string{
+		"server.host": "env.example.com",
+		"url":         "http://env.example.com:8080/",
+		"fallback":    "default",
+		"literal":     "${server.host}",
+	} {
+		value, err := inputSource.String(key)
+		expect(t, err, nil)
+		expect(t, value, expected)
+	}
+
+	timeout, err := inputSource.Duration("timeout")
+	expect(t, err, nil)
+	expect(t, timeout, 5*time.Second)
+
+	list, err := inputSource.StringSlice("list")
+	expect(t, err, nil)
+	expect(t, list, []string{"env.example.com", "plain"})
+
+	_, err = inputSource.String("self")
+	expect(t, err, errors.New("Unable to interpolate 'self': reference cycle self -> loop.a -> loop.b -> loop.a"))
+
+	_, err = inputSource.String("nested")
+	expect(t, err, errors.New("Unable to interpolate 'nested': 'server' is not a scalar value but map[interface {}]interface {}"))
+
+	_, err = inputSource.String("broken")
+	expect(t, err, errors.New(`Unable to interpolate 'broken': unterminated '${' in "${server.host"`))
+}
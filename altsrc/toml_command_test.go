@@ -121,6 +121,36 @@ func TestCommandTomlFileTestGlobalEnvVarWinsNested(t *testing.T) {
 	expect(t, err, nil)
 }
 
+func TestCommandTomlFileTestArrayOfTables(t *testing.T) {
+	app := &cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	_ = ioutil.WriteFile("current.toml", []byte("[[servers]]\nhost = \"a\"\n[[servers]]\nhost = \"b\""), 0666)
+	defer os.Remove("current.toml")
+
+	test := []string{"test-cmd", "--load", "current.toml"}
+	_ = set.Parse(test)
+
+	c := cli.NewContext(app, set, nil)
+
+	command := &cli.Command{
+		Name: "test-cmd",
+		Action: func(c *cli.Context) error {
+			expect(t, c.String("host"), "b")
+			expect(t, c.StringSlice("hosts"), []string{"a", "b"})
+			return nil
+		},
+		Flags: []cli.Flag{
+			&StringFlag{StringFlag: &cli.StringFlag{Name: "host"}, ConfigKeys: ConfigKeys{ConfigKey: "servers[1].host"}},
+			&StringSliceFlag{StringSliceFlag: &cli.StringSliceFlag{Name: "hosts"}, ConfigKeys: ConfigKeys{ConfigKey: "servers[*].host"}},
+			&cli.StringFlag{Name: "load"}},
+	}
+	command.Before = InitInputSourceWithContext(command.Flags, NewTomlSourceFromFlagFunc("load"))
+
+	err := command.Run(c)
+
+	expect(t, err, nil)
+}
+
 func TestCommandTomlFileTestSpecifiedFlagWins(t *testing.T) {
 	app := &cli.App{}
 	set := flag.NewFlagSet("test", 0)
@@ -68,7 +68,11 @@ func unmarshalMap(i interface{}) (ret map[interface{}]interface{}, err error) {
 				return nil, err
 			}
 		case reflect.Array, reflect.Slice:
-			ret[key] = val.([]interface{})
+			if tmp, err := unmarshalSlice(v); err == nil {
+				ret[key] = tmp
+			} else {
+				return nil, err
+			}
 		default:
 			return nil, fmt.Errorf("Unsupported: type = %#v", v.Kind())
 		}
@@ -76,6 +80,32 @@ func unmarshalMap(i interface{}) (ret map[interface{}]interface{}, err error) {
 	return ret, nil
 }
 
+// unmarshalSlice converts a TOML array, including arrays of tables, into a
+// []interface{} whose tables are converted by unmarshalMap.
+func unmarshalSlice(v reflect.Value) ([]interface{}, error) {
+	ret := make([]interface{}, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i).Interface()
+		switch elem.(type) {
+		case map[string]interface{}:
+			tmp, err := unmarshalMap(elem)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, tmp)
+		case []interface{}, []map[string]interface{}:
+			tmp, err := unmarshalSlice(reflect.ValueOf(elem))
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, tmp)
+		default:
+			ret = append(ret, elem)
+		}
+	}
+	return ret, nil
+}
+
 func (tm *tomlMap) UnmarshalTOML(i interface{}) error {
 	if tmp, err := unmarshalMap(i); err == nil {
 		tm.Map = tmp
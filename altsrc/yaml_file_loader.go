@@ -16,12 +16,8 @@ package altsrc
 
 import (
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"net/url"
-	"os"
 	"runtime"
-	"strings"
 
 	"gopkg.in/yaml.v2"
 
@@ -68,34 +64,22 @@ func readCommandYaml(filePath string, container interface{}) (err error) {
 }
 
 func loadDataFrom(filePath string) ([]byte, error) {
+	if filePath == "-" {
+		return fetch(&url.URL{Scheme: "stdin"}, filePath)
+	}
+
 	u, err := url.Parse(filePath)
 	if err != nil {
 		return nil, err
 	}
 
-	if u.Host != "" { // i have a host, now do i support the scheme?
-		switch u.Scheme {
-		case "http", "https":
-			res, err := http.Get(filePath)
-			if err != nil {
-				return nil, err
-			}
-			return ioutil.ReadAll(res.Body)
-		default:
-			return nil, fmt.Errorf("scheme of %s is unsupported", filePath)
-		}
-	} else if u.Path != "" { // i dont have a host, but I have a path. I am a local file.
-		if _, notFoundFileErr := os.Stat(filePath); notFoundFileErr != nil {
-			return nil, fmt.Errorf("Cannot read from file: '%s' because it does not exist.", filePath)
-		}
-		return ioutil.ReadFile(filePath)
-	} else if runtime.GOOS == "windows" && strings.Contains(u.String(), "\\") {
-		// on Windows systems u.Path is always empty, so we need to check the string directly.
-		if _, notFoundFileErr := os.Stat(filePath); notFoundFileErr != nil {
-			return nil, fmt.Errorf("Cannot read from file: '%s' because it does not exist.", filePath)
+	// on Windows systems a drive letter is parsed as the url scheme
+	if u.Scheme == "" || (runtime.GOOS == "windows" && len(u.Scheme) == 1) {
+		if filePath == "" {
+			return nil, fmt.Errorf("unable to determine how to load from path %s", filePath)
 		}
-		return ioutil.ReadFile(filePath)
+		return fetch(&url.URL{Scheme: "file", Path: filePath}, filePath)
 	}
 
-	return nil, fmt.Errorf("unable to determine how to load from path %s", filePath)
+	return fetch(u, filePath)
 }
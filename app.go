@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 )
 
@@ -69,6 +70,10 @@ type App struct {
 	Commands []*Command
 	// List of flags to parse
 	Flags []Flag
+	// List of flags to parse, which are also accepted after any subcommand.
+	// A value given after the subcommand replaces the one given before it,
+	// except for slice and map flags whose values add up.
+	PersistentFlags []Flag
 	// Boolean to enable bash completion commands
 	EnableBashCompletion bool
 	// Boolean to hide built-in help command
@@ -87,9 +92,13 @@ type App struct {
 	After AfterFunc
 	// The action to execute when no subcommands are specified
 	Action ActionFunc
-	// Execute this function if the proper command cannot be found
+	// Execute this function if the proper command cannot be found, also
+	// instead of failing with an unknown command error when
+	// RejectUnknownCommands is set. Context.Suggestion returns the closest
+	// command name
 	CommandNotFound CommandNotFoundFunc
 	// Execute this function if an usage error occurs
+	// Context.Suggestion returns the closest flag name to an unknown flag
 	OnUsageError OnUsageErrorFunc
 	// Compilation date
 	Compiled time.Time
@@ -116,6 +125,33 @@ type App struct {
 	// single-character bool arguments into one
 	// i.e. foobar -o -v -> foobar -ov
 	UseShortOptionHandling bool
+	// Boolean to accept flags after positional arguments
+	// i.e. foobar copy src dst --force
+	// Arguments after "--" are never treated as flags
+	AllowInterspersedFlags bool
+	// Boolean to accept unique prefixes of long flag and command names
+	// i.e. foobar stat --verb -> foobar status --verbose
+	// Hidden commands and flags must be given in full
+	AllowAbbreviations bool
+	// Boolean to not suggest the closest command or flag name when an
+	// unknown one is given
+	DisableSuggestions bool
+	// Boolean to fail with an "unknown command" error, or to call
+	// CommandNotFound, when the first argument matches no command, instead
+	// of running Action with it. Action can still read the closest command
+	// name from Context.Suggestion.
+	RejectUnknownCommands bool
+	// Boolean to replace @file arguments by the arguments read from the
+	// file, which are split like shell words and may include other files
+	// Arguments starting with @@ are passed on without the first @
+	EnableResponseFiles bool
+	// Boolean to accept yes/no, y/n, on/off and enabled/disabled in any case
+	// for all the bool flags of the app and its commands, as with their
+	// Lenient option
+	LenientBools bool
+
+	// persistent flags of the parent commands, set for subcommand apps
+	inheritedFlags []Flag
 
 	didSetup bool
 }
@@ -224,14 +260,31 @@ func (a *App) Setup() {
 	}
 }
 
+func (a *App) definedFlags() []Flag {
+	return a.allFlags()
+}
+
 func (a *App) newFlagSet() (*flag.FlagSet, error) {
-	return flagSet(a.Name, a.Flags)
+	return flagSetLenient(a.Name, a.allFlags(), a.LenientBools)
 }
 
 func (a *App) useShortOptionHandling() bool {
 	return a.UseShortOptionHandling
 }
 
+func (a *App) allowInterspersedFlags() bool {
+	return a.AllowInterspersedFlags
+}
+
+func (a *App) allowAbbreviations() bool {
+	return a.AllowAbbreviations
+}
+
+func (a *App) hasCommand(name string) bool {
+	c, _ := a.findCommand(name)
+	return c != nil
+}
+
 // Run is the entry point to the cli app. Parses the arguments slice and routes
 // to the proper flag/args combination
 func (a *App) Run(arguments []string) (err error) {
@@ -252,13 +305,21 @@ func (a *App) RunContext(ctx context.Context, arguments []string) (err error) {
 	// always appends the completion flag at the end of the command
 	shellComplete, arguments := checkShellCompleteFlag(a, arguments)
 
+	if a.EnableResponseFiles && len(arguments) > 1 {
+		expanded, err := expandResponseFiles(arguments[1:])
+		if err != nil {
+			return err
+		}
+		arguments = append([]string{arguments[0]}, expanded...)
+	}
+
 	set, err := a.newFlagSet()
 	if err != nil {
 		return err
 	}
 
 	err = parseIter(set, a, arguments[1:], shellComplete)
-	nerr := normalizeFlags(a.Flags, set)
+	nerr := normalizeFlags(a.allFlags(), set)
 	context := NewContext(a, set, &Context{Context: ctx})
 	if nerr != nil {
 		_, _ = fmt.Fprintln(a.Writer, nerr)
@@ -272,12 +333,16 @@ func (a *App) RunContext(ctx context.Context, arguments []string) (err error) {
 	}
 
 	if err != nil {
+		context.suggestion = a.suggestFlagFromError(err, a.allFlags())
 		if a.OnUsageError != nil {
 			err := a.OnUsageError(context, err, false)
 			a.handleExitCoder(context, err)
 			return err
 		}
 		_, _ = fmt.Fprintf(a.Writer, "%s %s\n\n", "Incorrect Usage.", err.Error())
+		if context.suggestion != "" {
+			_, _ = fmt.Fprintf(a.Writer, "%s\n\n", context.suggestion)
+		}
 		_ = ShowAppHelp(context)
 		return err
 	}
@@ -294,12 +359,14 @@ func (a *App) RunContext(ctx context.Context, arguments []string) (err error) {
 		return nil
 	}
 
-	cerr := checkRequiredFlags(a.Flags, context)
+	cerr := checkRequiredFlags(a.requiredFlags(context), context)
 	if cerr != nil {
 		_ = ShowAppHelp(context)
 		return cerr
 	}
 
+	defer closeFileFlags(context.flagSet, &err)
+
 	if a.After != nil {
 		defer func() {
 			if afterErr := a.After(context); afterErr != nil {
@@ -325,11 +392,17 @@ func (a *App) RunContext(ctx context.Context, arguments []string) (err error) {
 
 	args := context.Args()
 	if args.Present() {
-		name := args.First()
-		c := a.Command(name)
+		c, cerr := a.findCommand(args.First())
+		if cerr != nil {
+			a.handleExitCoder(context, cerr)
+			return cerr
+		}
 		if c != nil {
 			return c.Run(context)
 		}
+		if done, uerr := a.handleUnknownCommand(context, args.First()); done {
+			return uerr
+		}
 	}
 
 	if a.Action == nil {
@@ -386,7 +459,10 @@ func (a *App) RunAsSubcommand(ctx *Context) (err error) {
 	}
 
 	err = parseIter(set, a, ctx.Args().Tail(), ctx.shellComplete)
-	nerr := normalizeFlags(a.Flags, set)
+	nerr := normalizeFlags(a.allFlags(), set)
+	if err == nil && nerr == nil {
+		inheritFlagValues(a.inheritedFlags, set, ctx)
+	}
 	context := NewContext(a, set, ctx)
 
 	if nerr != nil {
@@ -405,12 +481,16 @@ func (a *App) RunAsSubcommand(ctx *Context) (err error) {
 	}
 
 	if err != nil {
+		context.suggestion = a.suggestFlagFromError(err, a.allFlags())
 		if a.OnUsageError != nil {
 			err = a.OnUsageError(context, err, true)
 			a.handleExitCoder(context, err)
 			return err
 		}
 		_, _ = fmt.Fprintf(a.Writer, "%s %s\n\n", "Incorrect Usage.", err.Error())
+		if context.suggestion != "" {
+			_, _ = fmt.Fprintf(a.Writer, "%s\n\n", context.suggestion)
+		}
 		_ = ShowSubcommandHelp(context)
 		return err
 	}
@@ -425,12 +505,14 @@ func (a *App) RunAsSubcommand(ctx *Context) (err error) {
 		}
 	}
 
-	cerr := checkRequiredFlags(a.Flags, context)
+	cerr := checkRequiredFlags(a.requiredFlags(context), context)
 	if cerr != nil {
 		_ = ShowSubcommandHelp(context)
 		return cerr
 	}
 
+	defer closeFileFlags(context.flagSet, &err)
+
 	if a.After != nil {
 		defer func() {
 			afterErr := a.After(context)
@@ -456,11 +538,17 @@ func (a *App) RunAsSubcommand(ctx *Context) (err error) {
 
 	args := context.Args()
 	if args.Present() {
-		name := args.First()
-		c := a.Command(name)
+		c, cerr := a.findCommand(args.First())
+		if cerr != nil {
+			a.handleExitCoder(context, cerr)
+			return cerr
+		}
 		if c != nil {
 			return c.Run(context)
 		}
+		if done, uerr := a.handleUnknownCommand(context, args.First()); done {
+			return uerr
+		}
 	}
 
 	// Run default Action
@@ -477,9 +565,90 @@ func (a *App) Command(name string) *Command {
 			return c
 		}
 	}
+
 	return nil
 }
 
+// findCommand looks up the command named on the command line. With
+// AllowAbbreviations, name may also be a unique prefix of a visible command
+// name, an ambiguous prefix being an error.
+func (a *App) findCommand(name string) (*Command, error) {
+	if c := a.Command(name); c != nil {
+		return c, nil
+	}
+
+	if !a.AllowAbbreviations || name == "" {
+		return nil, nil
+	}
+
+	var found []*Command
+	for _, c := range a.Commands {
+		if c.Hidden {
+			continue
+		}
+		for _, n := range c.Names() {
+			if strings.HasPrefix(n, name) {
+				found = append(found, c)
+				break
+			}
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return found[0], nil
+	}
+
+	candidates := make([]string, 0, len(found))
+	for _, c := range found {
+		candidates = append(candidates, c.Name)
+	}
+	return nil, fmt.Errorf("ambiguous command: %s could be %s", name, strings.Join(candidates, ", "))
+}
+
+// handleUnknownCommand deals with a first argument naming no command of an
+// app having commands. The closest command name becomes the suggestion of
+// the context. When the app rejects unknown commands, the argument goes to
+// CommandNotFound or else is an error, and the action is skipped. It returns
+// whether the action must be skipped.
+func (a *App) handleUnknownCommand(ctx *Context, name string) (bool, error) {
+	if !a.hasSubcommands() {
+		return false, nil
+	}
+
+	if !a.DisableSuggestions {
+		ctx.suggestion = SuggestCommand(a.Commands, name)
+	}
+
+	if !a.RejectUnknownCommands {
+		return false, nil
+	}
+	if a.CommandNotFound != nil {
+		a.CommandNotFound(ctx, name)
+		return true, nil
+	}
+
+	msg := fmt.Sprintf("Unknown command '%v'", name)
+	if ctx.suggestion != "" {
+		msg += ". " + ctx.suggestion
+	}
+	err := Exit(msg, 3)
+	a.handleExitCoder(ctx, err)
+	return true, err
+}
+
+// hasSubcommands returns whether the app has commands besides help
+func (a *App) hasSubcommands() bool {
+	for _, c := range a.Commands {
+		if c != helpCommand && c != helpSubcommand {
+			return true
+		}
+	}
+	return false
+}
+
 // VisibleCategories returns a slice of categories and commands that are
 // Hidden=false
 func (a *App) VisibleCategories() []CommandCategory {
@@ -508,9 +677,40 @@ func (a *App) VisibleCommands() []*Command {
 	return ret
 }
 
-// VisibleFlags returns a slice of the Flags with Hidden=false
+// VisibleFlags returns a slice of the Flags and PersistentFlags with
+// Hidden=false
 func (a *App) VisibleFlags() []Flag {
-	return visibleFlags(a.Flags)
+	return visibleFlags(a.localFlags())
+}
+
+// VisibleInheritedFlags returns a slice of the persistent flags of the parent
+// commands with Hidden=false
+func (a *App) VisibleInheritedFlags() []Flag {
+	return visibleFlags(a.inheritedFlags)
+}
+
+// localFlags returns the flags defined on the app itself
+func (a *App) localFlags() []Flag {
+	flags := make([]Flag, 0, len(a.Flags)+len(a.PersistentFlags))
+	flags = append(flags, a.Flags...)
+	return append(flags, a.PersistentFlags...)
+}
+
+// allFlags returns the flags parsed by the app, including inherited ones
+func (a *App) allFlags() []Flag {
+	return append(a.localFlags(), a.inheritedFlags...)
+}
+
+// requiredFlags returns the flags whose presence is checked before running.
+// Persistent and inherited flags may still be given after a subcommand, so
+// they are left to the command that ends up running.
+func (a *App) requiredFlags(ctx *Context) []Flag {
+	if args := ctx.Args(); args.Present() {
+		if c, _ := a.findCommand(args.First()); c != nil {
+			return a.Flags
+		}
+	}
+	return a.allFlags()
 }
 
 func (a *App) errWriter() io.Writer {
@@ -8,6 +8,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -675,6 +676,387 @@ func TestApp_UseShortOptionHandling_missing_value(t *testing.T) {
 	expect(t, err, errors.New("flag needs an argument: -n"))
 }
 
+func TestApp_UseShortOptionHandling_AttachedValue(t *testing.T) {
+	cases := []struct {
+		args    []string
+		verbose bool
+		output  string
+	}{
+		{args: []string{"", "-ofile"}, output: "file"},
+		{args: []string{"", "-o=file"}, output: "file"},
+		{args: []string{"", "-vofile"}, verbose: true, output: "file"},
+		{args: []string{"", "-vo=file"}, verbose: true, output: "file"},
+		{args: []string{"", "-vo", "file"}, verbose: true, output: "file"},
+		{args: []string{"", "-vo-"}, verbose: true, output: "-"},
+		{args: []string{"", "-ov"}, output: "v"},
+	}
+
+	for _, c := range cases {
+		var verbose bool
+		var output string
+
+		app := newTestApp()
+		app.UseShortOptionHandling = true
+		app.Flags = []Flag{
+			&BoolFlag{Name: "verbose", Aliases: []string{"v"}},
+			&StringFlag{Name: "output", Aliases: []string{"o"}},
+		}
+		app.Action = func(c *Context) error {
+			verbose = c.Bool("verbose")
+			output = c.String("output")
+			return nil
+		}
+
+		err := app.Run(c.args)
+		expect(t, err, nil)
+		expect(t, verbose, c.verbose)
+		expect(t, output, c.output)
+	}
+}
+
+func TestApp_UseShortOptionHandling_Interspersed(t *testing.T) {
+	var extract, verbose bool
+	var file string
+	var args []string
+
+	app := newTestApp()
+	app.UseShortOptionHandling = true
+	app.AllowInterspersedFlags = true
+	app.Flags = []Flag{
+		&BoolFlag{Name: "x"},
+		&BoolFlag{Name: "v"},
+		&StringFlag{Name: "f"},
+	}
+	app.Action = func(c *Context) error {
+		extract = c.Bool("x")
+		verbose = c.Bool("v")
+		file = c.String("f")
+		args = c.Args().Slice()
+		return nil
+	}
+
+	err := app.Run([]string{"", "dir", "-xvf", "archive.tar", "-vfother.tar"})
+	expect(t, err, nil)
+	expect(t, extract, true)
+	expect(t, verbose, true)
+	expect(t, file, "other.tar")
+	expect(t, args, []string{"dir"})
+}
+
+func TestApp_SuggestFlag(t *testing.T) {
+	for _, disable := range []bool{false, true} {
+		output := &bytes.Buffer{}
+		app := &App{
+			Writer:             output,
+			DisableSuggestions: disable,
+			Flags:              []Flag{&BoolFlag{Name: "verbose"}},
+			Commands: []*Command{
+				{
+					Name:   "run",
+					Flags:  []Flag{&StringFlag{Name: "output"}},
+					Action: func(ctx *Context) error { return nil },
+				},
+			},
+		}
+
+		err := app.Run([]string{"", "--verbos"})
+		expect(t, err.Error(), "flag provided but not defined: -verbos")
+		expect(t, strings.Contains(output.String(), `Did you mean "--verbose"?`), !disable)
+
+		output.Reset()
+		err = app.Run([]string{"", "run", "--ouptut", "file"})
+		expect(t, err.Error(), "flag provided but not defined: -ouptut")
+		expect(t, strings.Contains(output.String(), `Did you mean "--output"?`), !disable)
+	}
+}
+
+func TestApp_RejectUnknownCommands(t *testing.T) {
+	cases := []struct {
+		args     []string
+		reject   bool
+		commands bool
+		err      string
+		ran      bool
+	}{
+		{args: []string{"", "deplyo"}, reject: true, commands: true, err: `Unknown command 'deplyo'. Did you mean "deploy"?`},
+		{args: []string{"", "remote", "ad"}, reject: true, commands: true, err: `Unknown command 'ad'. Did you mean "add"?`},
+		{args: []string{"", "xyz"}, reject: true, commands: true, err: "Unknown command 'xyz'"},
+		{args: []string{"", "deploy"}, reject: true, commands: true, ran: true},
+		{args: []string{"", "file.txt"}, reject: true, ran: true},
+		{args: []string{"", "deplyo"}, commands: true, ran: true},
+	}
+
+	for _, c := range cases {
+		var ran bool
+		var exitErr error
+		action := func(ctx *Context) error {
+			ran = true
+			return nil
+		}
+
+		app := newTestApp()
+		app.RejectUnknownCommands = c.reject
+		app.ExitErrHandler = func(ctx *Context, err error) {
+			exitErr = err
+		}
+		app.Action = action
+		if c.commands {
+			app.Commands = []*Command{
+				{Name: "deploy", Action: action},
+				{Name: "remote", Subcommands: []*Command{{Name: "add", Action: action}}},
+			}
+		}
+
+		err := app.Run(c.args)
+		expect(t, ran, c.ran)
+		if c.err == "" {
+			expect(t, err, nil)
+			continue
+		}
+
+		expect(t, err.Error(), c.err)
+		expect(t, exitErr, err)
+		expect(t, err.(ExitCoder).ExitCode(), 3)
+	}
+}
+
+func TestApp_ResponseFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "urfave_cli_test")
+	expect(t, err, nil)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"args.txt":        "# deploy settings\n--name 'my app' \"--label=a \\\"b\\\"\"\n@nested/more.txt\n@@literal  # trailing comment\n",
+		"nested/more.txt": "--label c\\ d\n-- @not-expanded\n",
+		"loop.txt":        "@loop.txt\n",
+		"quote.txt":       "--name 'unterminated\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		expect(t, os.MkdirAll(filepath.Dir(path), 0755), nil)
+		expect(t, ioutil.WriteFile(path, []byte(content), 0644), nil)
+	}
+
+	var name string
+	var labels, args []string
+	app := newTestApp()
+	app.EnableResponseFiles = true
+	app.Flags = []Flag{
+		&StringFlag{Name: "name"},
+		&StringSliceFlag{Name: "label"},
+	}
+	app.Action = func(ctx *Context) error {
+		name = ctx.String("name")
+		labels = ctx.StringSlice("label")
+		args = ctx.Args().Slice()
+		return nil
+	}
+
+	err = app.Run([]string{"", "@" + filepath.Join(dir, "args.txt"), "@other"})
+	expect(t, err, nil)
+	expect(t, name, "my app")
+	expect(t, labels, []string{`a "b"`, "c d"})
+	expect(t, args, []string{"@not-expanded", "@@literal", "@other"})
+
+	err = app.Run([]string{"", "--name", "@@at", "@@arg"})
+	expect(t, err, nil)
+	expect(t, name, "@at")
+	expect(t, args, []string{"@arg"})
+
+	err = app.Run([]string{"", "@" + filepath.Join(dir, "loop.txt")})
+	expect(t, err.Error(), "response file loop.txt includes itself")
+
+	err = app.Run([]string{"", "@" + filepath.Join(dir, "quote.txt")})
+	expect(t, strings.HasSuffix(err.Error(), "quote.txt: unterminated ' quote"), true)
+
+	err = app.Run([]string{"", "@" + filepath.Join(dir, "missing.txt")})
+	expect(t, strings.HasPrefix(err.Error(), "unable to read response file"), true)
+
+	app.EnableResponseFiles = false
+	err = app.Run([]string{"", "@args.txt"})
+	expect(t, err, nil)
+	expect(t, args, []string{"@args.txt"})
+}
+
+func TestApp_SuggestionInCallbacks(t *testing.T) {
+	var suggestions []string
+	onUsageError := func(ctx *Context, err error, _ bool) error {
+		suggestions = append(suggestions, ctx.Suggestion())
+		return err
+	}
+	app := &App{
+		Writer:       ioutil.Discard,
+		Flags:        []Flag{&BoolFlag{Name: "verbose"}},
+		OnUsageError: onUsageError,
+		CommandNotFound: func(ctx *Context, name string) {
+			suggestions = append(suggestions, name+": "+ctx.Suggestion())
+		},
+		Action: func(ctx *Context) error {
+			suggestions = append(suggestions, "action: "+ctx.Suggestion())
+			return nil
+		},
+		Commands: []*Command{
+			{
+				Name:         "deploy",
+				Flags:        []Flag{&StringFlag{Name: "output"}},
+				OnUsageError: onUsageError,
+				Subcommands: []*Command{
+					{Name: "status", Action: func(ctx *Context) error { return nil }},
+				},
+			},
+		},
+	}
+
+	_ = app.Run([]string{"", "--verbos"})
+	_ = app.Run([]string{"", "deploy", "--ouptut", "file"})
+	expect(t, app.Run([]string{"", "deplyo"}), nil)
+	expect(t, app.Run([]string{"", "something"}), nil)
+	expect(t, suggestions, []string{
+		`Did you mean "--verbose"?`,
+		`Did you mean "--output"?`,
+		`action: Did you mean "deploy"?`,
+		"action: ",
+	})
+
+	suggestions = nil
+	app.RejectUnknownCommands = true
+	expect(t, app.Run([]string{"", "deplyo"}), nil)
+	expect(t, app.Run([]string{"", "something"}), nil)
+	expect(t, app.Run([]string{"", "deploy", "staus"}), nil)
+	expect(t, suggestions, []string{
+		`deplyo: Did you mean "deploy"?`,
+		"something: ",
+		`staus: Did you mean "status"?`,
+	})
+
+	suggestions = nil
+	app.RejectUnknownCommands = false
+	app.DisableSuggestions = true
+	_ = app.Run([]string{"", "--verbos"})
+	expect(t, app.Run([]string{"", "deplyo"}), nil)
+	expect(t, suggestions, []string{"", "action: "})
+}
+
+func TestApp_SuggestCommandWithoutAction(t *testing.T) {
+	app := &App{
+		Writer:         ioutil.Discard,
+		ExitErrHandler: func(*Context, error) {},
+		Commands:       []*Command{{Name: "deploy"}},
+	}
+
+	err := app.Run([]string{"", "deplyo"})
+	expect(t, err.Error(), `No help topic for 'deplyo'. Did you mean "deploy"?`)
+}
+
+func TestApp_AllowAbbreviations(t *testing.T) {
+	cases := []struct {
+		args     []string
+		command  string
+		verbose  bool
+		level    string
+		err      error
+		disabled bool
+	}{
+		{args: []string{"", "stat"}, command: "status"},
+		{args: []string{"", "--verb", "stat", "--lev", "debug"}, command: "status", verbose: true, level: "debug"},
+		{args: []string{"", "stat", "--lev=info"}, command: "status", level: "info"},
+		{args: []string{"", "stat", "--level", "--verb"}, command: "status", level: "--verb"},
+		{args: []string{"", "st"}, err: errors.New("ambiguous command: st could be start, status")},
+		{args: []string{"", "--ver", "start"}, err: errors.New("ambiguous flag: --ver could be --verbose, --version")},
+		{args: []string{"", "sec"}},
+		{args: []string{"", "secret"}, command: "secret"},
+		{args: []string{"", "stat"}, disabled: true},
+		{args: []string{"", "--verb"}, disabled: true, err: errors.New("flag provided but not defined: -verb")},
+	}
+
+	for _, c := range cases {
+		var command, level string
+		var verbose bool
+		action := func(ctx *Context) error {
+			command = ctx.Command.Name
+			verbose = ctx.Bool("verbose")
+			level = ctx.String("level")
+			return nil
+		}
+
+		app := newTestApp()
+		app.AllowAbbreviations = !c.disabled
+		app.Action = func(ctx *Context) error {
+			command = ""
+			return nil
+		}
+		app.Flags = []Flag{
+			&BoolFlag{Name: "verbose"},
+			&BoolFlag{Name: "version"},
+		}
+		app.Commands = []*Command{
+			{Name: "start", Action: action},
+			{Name: "status", Action: action, Flags: []Flag{&StringFlag{Name: "level"}}},
+			{Name: "secret", Action: action, Hidden: true},
+		}
+
+		err := app.Run(c.args)
+		if c.err != nil {
+			expect(t, err.Error(), c.err.Error())
+			continue
+		}
+
+		expect(t, err, nil)
+		expect(t, command, c.command)
+		expect(t, verbose, c.verbose)
+		expect(t, level, c.level)
+	}
+}
+
+func TestApp_AllowAbbreviationsInterspersed(t *testing.T) {
+	var output string
+	var args []string
+	app := newTestApp()
+	app.AllowAbbreviations = true
+	app.AllowInterspersedFlags = true
+	app.Flags = []Flag{
+		&StringFlag{Name: "output"},
+		&BoolFlag{Name: "verbose"},
+	}
+	app.Action = func(ctx *Context) error {
+		output = ctx.String("output")
+		args = ctx.Args().Slice()
+		return nil
+	}
+
+	err := app.Run([]string{"", "src", "--out", "dst", "--verb", "x"})
+	expect(t, err, nil)
+	expect(t, output, "dst")
+	expect(t, args, []string{"src", "x"})
+}
+
+func TestApp_AllowAbbreviationsKeepsHelp(t *testing.T) {
+	var ran bool
+	app := newTestApp()
+	app.AllowAbbreviations = true
+	app.Commands = []*Command{
+		{Name: "helper", Action: func(*Context) error {
+			ran = true
+			return nil
+		}},
+	}
+
+	expect(t, app.Command("help"), (*Command)(nil))
+	expect(t, app.Command("hel"), (*Command)(nil))
+
+	app.Setup()
+	expect(t, app.Command("help"), helpCommand)
+	expect(t, app.Flags[0], HelpFlag)
+
+	c, err := app.findCommand("help")
+	expect(t, err, nil)
+	expect(t, c, helpCommand)
+
+	err = app.Run([]string{"", "helpe"})
+	expect(t, err, nil)
+	expect(t, ran, true)
+}
+
 func TestApp_UseShortOptionHandlingCommand(t *testing.T) {
 	var one, two bool
 	var name string
@@ -847,7 +1229,6 @@ func TestApp_ParseSliceFlags(t *testing.T) {
 	}
 }
 
-
 //func TestApp_ParseSliceFlagsWithMissingValue(t *testing.T) {
 //	var parsedIntSlice []int
 //	var parsedStringSlice []string
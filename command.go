@@ -53,6 +53,10 @@ type Command struct {
 	Subcommands []*Command
 	// List of flags to parse
 	Flags []Flag
+	// List of flags to parse, which are also accepted after any subcommand.
+	// A value given after the subcommand replaces the one given before it,
+	// except for slice and map flags whose values add up.
+	PersistentFlags []Flag
 	// Treat all flags as normal arguments if true
 	SkipFlagParsing bool
 	// Boolean to hide built-in help command
@@ -63,11 +67,24 @@ type Command struct {
 	// single-character bool arguments into one
 	// i.e. foobar -o -v -> foobar -ov
 	UseShortOptionHandling bool
+	// Boolean to accept flags after positional arguments
+	// i.e. foobar copy src dst --force
+	// Arguments after "--" are never treated as flags
+	AllowInterspersedFlags bool
+	// Boolean to accept unique prefixes of long flag and subcommand names
+	// i.e. foobar stat --verb -> foobar status --verbose
+	// Hidden subcommands and flags must be given in full
+	AllowAbbreviations bool
 
 	// Full name of command for help, defaults to full command name, including parent commands.
 	HelpName        string
 	commandNamePath []string
 
+	// persistent flags of the parent commands, set when the command runs
+	inheritedFlags []Flag
+	// LenientBools of the app running the command
+	lenientBools bool
+
 	// CustomHelpTemplate the text template for the command help topic.
 	// cli.go uses text/template to render templates. You can
 	// render custom help text by setting this variable.
@@ -114,7 +131,21 @@ func (c *Command) Run(ctx *Context) (err error) {
 		c.UseShortOptionHandling = true
 	}
 
+	if ctx.App.AllowInterspersedFlags {
+		c.AllowInterspersedFlags = true
+	}
+
+	if ctx.App.AllowAbbreviations {
+		c.AllowAbbreviations = true
+	}
+
+	c.lenientBools = ctx.App.LenientBools
+
+	c.inheritedFlags = inheritedFlags(ctx, c.localFlags())
 	set, err := c.parseFlags(ctx.Args(), ctx.shellComplete)
+	if err == nil {
+		inheritFlagValues(c.inheritedFlags, set, ctx)
+	}
 
 	context := NewContext(ctx.App, set, ctx)
 	context.Command = c
@@ -123,6 +154,7 @@ func (c *Command) Run(ctx *Context) (err error) {
 	}
 
 	if err != nil {
+		context.suggestion = context.App.suggestFlagFromError(err, c.allFlags())
 		if c.OnUsageError != nil {
 			err = c.OnUsageError(context, err, false)
 			context.App.handleExitCoder(context, err)
@@ -130,6 +162,10 @@ func (c *Command) Run(ctx *Context) (err error) {
 		}
 		_, _ = fmt.Fprintln(context.App.Writer, "Incorrect Usage:", err.Error())
 		_, _ = fmt.Fprintln(context.App.Writer)
+		if context.suggestion != "" {
+			_, _ = fmt.Fprintln(context.App.Writer, context.suggestion)
+			_, _ = fmt.Fprintln(context.App.Writer)
+		}
 		_ = ShowCommandHelp(context, c.Name)
 		return err
 	}
@@ -138,12 +174,14 @@ func (c *Command) Run(ctx *Context) (err error) {
 		return nil
 	}
 
-	cerr := checkRequiredFlags(c.Flags, context)
+	cerr := checkRequiredFlags(c.allFlags(), context)
 	if cerr != nil {
 		_ = ShowCommandHelp(context, c.Name)
 		return cerr
 	}
 
+	defer closeFileFlags(context.flagSet, &err)
+
 	if c.After != nil {
 		defer func() {
 			afterErr := c.After(context)
@@ -180,14 +218,35 @@ func (c *Command) Run(ctx *Context) (err error) {
 	return err
 }
 
+func (c *Command) definedFlags() []Flag {
+	return c.allFlags()
+}
+
 func (c *Command) newFlagSet() (*flag.FlagSet, error) {
-	return flagSet(c.Name, c.Flags)
+	return flagSetLenient(c.Name, c.allFlags(), c.lenientBools)
 }
 
 func (c *Command) useShortOptionHandling() bool {
 	return c.UseShortOptionHandling
 }
 
+func (c *Command) allowInterspersedFlags() bool {
+	return c.AllowInterspersedFlags
+}
+
+func (c *Command) allowAbbreviations() bool {
+	return c.AllowAbbreviations
+}
+
+func (c *Command) hasCommand(name string) bool {
+	for _, sc := range c.Subcommands {
+		if sc.HasName(name) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Command) parseFlags(args Args, shellComplete bool) (*flag.FlagSet, error) {
 	set, err := c.newFlagSet()
 	if err != nil {
@@ -203,7 +262,7 @@ func (c *Command) parseFlags(args Args, shellComplete bool) (*flag.FlagSet, erro
 		return nil, err
 	}
 
-	err = normalizeFlags(c.Flags, set)
+	err = normalizeFlags(c.allFlags(), set)
 	if err != nil {
 		return nil, err
 	}
@@ -249,6 +308,8 @@ func (c *Command) startApp(ctx *Context) error {
 	// set the flags and commands
 	app.Commands = c.Subcommands
 	app.Flags = c.Flags
+	app.PersistentFlags = c.PersistentFlags
+	app.inheritedFlags = inheritedFlags(ctx, c.localFlags())
 	app.HideHelp = c.HideHelp
 
 	app.Version = ctx.App.Version
@@ -258,6 +319,11 @@ func (c *Command) startApp(ctx *Context) error {
 	app.ErrWriter = ctx.App.ErrWriter
 	app.ExitErrHandler = ctx.App.ExitErrHandler
 	app.UseShortOptionHandling = ctx.App.UseShortOptionHandling
+	app.AllowInterspersedFlags = ctx.App.AllowInterspersedFlags || c.AllowInterspersedFlags
+	app.AllowAbbreviations = ctx.App.AllowAbbreviations || c.AllowAbbreviations
+	app.DisableSuggestions = ctx.App.DisableSuggestions
+	app.RejectUnknownCommands = ctx.App.RejectUnknownCommands
+	app.LenientBools = ctx.App.LenientBools
 
 	app.categories = newCommandCategories()
 	for _, command := range c.Subcommands {
@@ -289,9 +355,28 @@ func (c *Command) startApp(ctx *Context) error {
 	return app.RunAsSubcommand(ctx)
 }
 
-// VisibleFlags returns a slice of the Flags with Hidden=false
+// VisibleFlags returns a slice of the Flags and PersistentFlags with
+// Hidden=false
 func (c *Command) VisibleFlags() []Flag {
-	return visibleFlags(c.Flags)
+	return visibleFlags(c.localFlags())
+}
+
+// VisibleInheritedFlags returns a slice of the persistent flags of the parent
+// commands with Hidden=false
+func (c *Command) VisibleInheritedFlags() []Flag {
+	return visibleFlags(c.inheritedFlags)
+}
+
+// localFlags returns the flags defined on the command itself
+func (c *Command) localFlags() []Flag {
+	flags := make([]Flag, 0, len(c.Flags)+len(c.PersistentFlags))
+	flags = append(flags, c.Flags...)
+	return append(flags, c.PersistentFlags...)
+}
+
+// allFlags returns the flags parsed by the command, including inherited ones
+func (c *Command) allFlags() []Flag {
+	return append(c.localFlags(), c.inheritedFlags...)
 }
 
 func (c *Command) appendFlag(fl Flag) {
@@ -62,8 +62,8 @@ func TestParseAndRunShortOpts(t *testing.T) {
 		{testArgs: args{"foo", "test", "-cf"}, expectedErr: nil, expectedArgs: &args{}},
 		{testArgs: args{"foo", "test", "-acf"}, expectedErr: nil, expectedArgs: &args{}},
 		{testArgs: args{"foo", "test", "--acf"}, expectedErr: errors.New("flag provided but not defined: -acf"), expectedArgs: nil},
-		{testArgs: args{"foo", "test", "-invalid"}, expectedErr: errors.New("flag provided but not defined: -invalid"), expectedArgs: nil},
-		{testArgs: args{"foo", "test", "-acf", "-invalid"}, expectedErr: errors.New("flag provided but not defined: -invalid"), expectedArgs: nil},
+		{testArgs: args{"foo", "test", "-undefined"}, expectedErr: errors.New("flag provided but not defined: -undefined"), expectedArgs: nil},
+		{testArgs: args{"foo", "test", "-acf", "-undefined"}, expectedErr: errors.New("flag provided but not defined: -undefined"), expectedArgs: nil},
 		{testArgs: args{"foo", "test", "--invalid"}, expectedErr: errors.New("flag provided but not defined: -invalid"), expectedArgs: nil},
 		{testArgs: args{"foo", "test", "-acf", "--invalid"}, expectedErr: errors.New("flag provided but not defined: -invalid"), expectedArgs: nil},
 		{testArgs: args{"foo", "test", "-acf", "arg1", "-invalid"}, expectedErr: nil, expectedArgs: &args{"arg1", "-invalid"}},
@@ -71,6 +71,8 @@ func TestParseAndRunShortOpts(t *testing.T) {
 		{testArgs: args{"foo", "test", "-acfi", "not-arg", "arg1", "-invalid"}, expectedErr: nil, expectedArgs: &args{"arg1", "-invalid"}},
 		{testArgs: args{"foo", "test", "-i", "ivalue"}, expectedErr: nil, expectedArgs: &args{}},
 		{testArgs: args{"foo", "test", "-i", "ivalue", "arg1"}, expectedErr: nil, expectedArgs: &args{"arg1"}},
+		{testArgs: args{"foo", "test", "-invalid", "arg1"}, expectedErr: nil, expectedArgs: &args{"arg1"}},
+		{testArgs: args{"foo", "test", "-acivalue", "arg1"}, expectedErr: nil, expectedArgs: &args{"arg1"}},
 		{testArgs: args{"foo", "test", "-i"}, expectedErr: errors.New("flag needs an argument: -i"), expectedArgs: nil},
 	}
 
@@ -376,4 +378,263 @@ func TestCommand_Run_CustomShellCompleteAcceptsMalformedFlags(t *testing.T) {
 		expect(t, stdout, c.expectedOut)
 	}
 
+}
+
+func TestCommand_PersistentFlags(t *testing.T) {
+	cases := []struct {
+		testArgs       []string
+		expectedVerb   bool
+		expectedRegion string
+	}{
+		{testArgs: []string{"foo", "cloud", "deploy"}, expectedRegion: "us"},
+		{testArgs: []string{"foo", "--verbose", "cloud", "deploy"}, expectedVerb: true, expectedRegion: "us"},
+		{testArgs: []string{"foo", "cloud", "-V", "deploy", "--region", "eu"}, expectedVerb: true, expectedRegion: "eu"},
+		{testArgs: []string{"foo", "cloud", "--region", "eu", "deploy", "--verbose"}, expectedVerb: true, expectedRegion: "eu"},
+	}
+
+	for _, c := range cases {
+		var verbose bool
+		var region string
+		app := &App{
+			Writer: ioutil.Discard,
+			PersistentFlags: []Flag{
+				&BoolFlag{Name: "verbose", Aliases: []string{"V"}},
+			},
+			Commands: []*Command{
+				{
+					Name: "cloud",
+					PersistentFlags: []Flag{
+						&StringFlag{Name: "region", Value: "us"},
+					},
+					Subcommands: []*Command{
+						{
+							Name: "deploy",
+							Action: func(ctx *Context) error {
+								verbose = ctx.Bool("verbose")
+								region = ctx.String("region")
+								return nil
+							},
+						},
+					},
+				},
+			},
+		}
+
+		err := app.Run(c.testArgs)
+		expect(t, err, nil)
+		expect(t, verbose, c.expectedVerb)
+		expect(t, region, c.expectedRegion)
+	}
+}
+
+func TestCommand_PersistentFlags_SlicesAddUp(t *testing.T) {
+	cases := []struct {
+		testArgs     []string
+		expectedTags []string
+		expectedEnv  map[string]string
+		expectedName string
+	}{
+		{testArgs: []string{"foo", "deploy"}, expectedTags: []string{}, expectedEnv: map[string]string{}, expectedName: "app"},
+		{testArgs: []string{"foo", "--tag", "a", "--env", "k=1", "--name", "x", "deploy"}, expectedTags: []string{"a"}, expectedEnv: map[string]string{"k": "1"}, expectedName: "x"},
+		{testArgs: []string{"foo", "deploy", "--tag", "b", "--env", "l=2", "--name", "y"}, expectedTags: []string{"b"}, expectedEnv: map[string]string{"l": "2"}, expectedName: "y"},
+		{testArgs: []string{"foo", "--tag", "a", "--env", "k=1", "--name", "x", "deploy", "--tag", "b", "--env", "l=2", "--name", "y"}, expectedTags: []string{"a", "b"}, expectedEnv: map[string]string{"k": "1", "l": "2"}, expectedName: "y"},
+	}
+
+	for _, c := range cases {
+		var tags []string
+		var env map[string]string
+		var name string
+		app := &App{
+			Writer: ioutil.Discard,
+			PersistentFlags: []Flag{
+				&StringSliceFlag{Name: "tag"},
+				&StringMapFlag{Name: "env"},
+				&StringFlag{Name: "name", Value: "app"},
+			},
+			Commands: []*Command{
+				{
+					Name: "deploy",
+					Action: func(ctx *Context) error {
+						tags = ctx.StringSlice("tag")
+						env = ctx.StringMap("env")
+						name = ctx.String("name")
+						return nil
+					},
+				},
+			},
+		}
+
+		err := app.Run(c.testArgs)
+		expect(t, err, nil)
+		expect(t, tags, c.expectedTags)
+		expect(t, env, c.expectedEnv)
+		expect(t, name, c.expectedName)
+	}
+}
+
+func TestCommand_PersistentFlags_Required(t *testing.T) {
+	app := &App{
+		Writer: ioutil.Discard,
+		PersistentFlags: []Flag{
+			&StringFlag{Name: "token", Required: true},
+		},
+		Commands: []*Command{
+			{
+				Name:   "deploy",
+				Action: func(ctx *Context) error { return nil },
+			},
+		},
+	}
+
+	expect(t, app.Run([]string{"foo", "deploy", "--token", "t"}), nil)
+	expect(t, app.Run([]string{"foo", "--token", "t", "deploy"}), nil)
+
+	err := app.Run([]string{"foo", "deploy"})
+	expect(t, err.Error(), `Required flag "token" not set`)
+}
+
+func TestCommand_PersistentFlags_LocalFlagWins(t *testing.T) {
+	var level string
+	app := &App{
+		Writer: ioutil.Discard,
+		PersistentFlags: []Flag{
+			&StringFlag{Name: "level", Value: "global"},
+		},
+		Commands: []*Command{
+			{
+				Name:  "deploy",
+				Flags: []Flag{&StringFlag{Name: "level", Value: "local"}},
+				Action: func(ctx *Context) error {
+					level = ctx.String("level")
+					return nil
+				},
+			},
+		},
+	}
+
+	expect(t, app.Run([]string{"foo", "deploy"}), nil)
+	expect(t, level, "local")
+}
+
+func TestCommand_PersistentFlags_Help(t *testing.T) {
+	var output bytes.Buffer
+	app := &App{
+		Writer: &output,
+		PersistentFlags: []Flag{
+			&BoolFlag{Name: "verbose", Usage: "print more"},
+			&BoolFlag{Name: "debug", Hidden: true},
+		},
+		Commands: []*Command{
+			{
+				Name:   "deploy",
+				Flags:  []Flag{&StringFlag{Name: "target"}},
+				Action: func(ctx *Context) error { return nil },
+			},
+		},
+	}
+
+	err := app.Run([]string{"foo", "deploy", "--help"})
+	expect(t, err, nil)
+
+	help := output.String()
+	expect(t, strings.Contains(help, "OPTIONS:\n   --target string"), true)
+	expect(t, strings.Contains(help, "INHERITED OPTIONS:\n   --verbose  print more"), true)
+	expect(t, strings.Contains(help, "--debug"), false)
+}
+
+func TestCommand_AllowInterspersedFlags(t *testing.T) {
+	cases := []struct {
+		testArgs      args
+		expectedErr   error
+		expectedArgs  Args
+		expectedForce bool
+		expectedDst   string
+	}{
+		{testArgs: args{"foo", "copy", "src", "dst", "--force"}, expectedArgs: &args{"src", "dst"}, expectedForce: true},
+		{testArgs: args{"foo", "copy", "src", "-o", "out", "dst", "-f"}, expectedArgs: &args{"src", "dst"}, expectedForce: true, expectedDst: "out"},
+		{testArgs: args{"foo", "copy", "src", "--output=out", "-"}, expectedArgs: &args{"src", "-"}, expectedDst: "out"},
+		{testArgs: args{"foo", "copy", "src", "-fo", "out", "dst"}, expectedArgs: &args{"src", "dst"}, expectedForce: true, expectedDst: "out"},
+		{testArgs: args{"foo", "copy", "src", "--", "--force", "-o"}, expectedArgs: &args{"src", "--force", "-o"}},
+		{testArgs: args{"foo", "copy", "--", "-f"}, expectedArgs: &args{"-f"}},
+		{testArgs: args{"foo", "copy", "src", "--invalid"}, expectedErr: errors.New("flag provided but not defined: -invalid")},
+	}
+
+	for _, c := range cases {
+		var args Args
+		var force bool
+		var dst string
+		cmd := &Command{
+			Name:                   "copy",
+			UseShortOptionHandling: true,
+			AllowInterspersedFlags: true,
+			Action: func(c *Context) error {
+				args = c.Args()
+				force = c.Bool("force")
+				dst = c.String("output")
+				return nil
+			},
+			Flags: []Flag{
+				&BoolFlag{Name: "force", Aliases: []string{"f"}},
+				&StringFlag{Name: "output", Aliases: []string{"o"}},
+			},
+		}
+
+		app := newTestApp()
+		app.Commands = []*Command{cmd}
+
+		err := app.Run(c.testArgs)
+
+		expect(t, err, c.expectedErr)
+		expect(t, args, c.expectedArgs)
+		expect(t, force, c.expectedForce)
+		expect(t, dst, c.expectedDst)
+	}
+}
+
+func TestApp_AllowInterspersedFlags_StopsAtSubcommand(t *testing.T) {
+	var verbose, force bool
+	var cmdArgs Args
+	app := newTestApp()
+	app.AllowInterspersedFlags = true
+	app.Flags = []Flag{&BoolFlag{Name: "verbose"}}
+	app.Commands = []*Command{
+		{
+			Name:  "copy",
+			Flags: []Flag{&BoolFlag{Name: "force"}},
+			Action: func(c *Context) error {
+				verbose = c.Bool("verbose")
+				force = c.Bool("force")
+				cmdArgs = c.Args()
+				return nil
+			},
+		},
+	}
+
+	err := app.Run([]string{"foo", "--verbose", "copy", "src", "--force", "dst"})
+	expect(t, err, nil)
+	expect(t, verbose, true)
+	expect(t, force, true)
+	expect(t, cmdArgs, &args{"src", "dst"})
+}
+
+func TestCommand_AllowInterspersedFlags_ShellComplete(t *testing.T) {
+	var outputBuffer bytes.Buffer
+	app := &App{
+		Writer:                 &outputBuffer,
+		EnableBashCompletion:   true,
+		AllowInterspersedFlags: true,
+		Commands: []*Command{
+			{
+				Name:  "copy",
+				Flags: []Flag{&IntFlag{Name: "number"}},
+				BashComplete: func(c *Context) {
+					fmt.Fprintf(c.App.Writer, "found %d args", c.NArg())
+				},
+			},
+		},
+	}
+
+	err := app.Run([]string{"foo", "copy", "src", "--number", "42", "dst", "--generate-bash-completion"})
+	expect(t, err, nil)
+	expect(t, outputBuffer.String(), "found 2 args")
 }
\ No newline at end of file
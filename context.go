@@ -33,6 +33,7 @@ type Context struct {
 	shellComplete bool
 	flagSet       *flag.FlagSet
 	parentContext *Context
+	suggestion    string
 }
 
 // NewContext creates a new context. For use in when invoking an App or Command action.
@@ -55,6 +56,13 @@ func NewContext(app *App, set *flag.FlagSet, parentCtx *Context) *Context {
 	return c
 }
 
+// Suggestion returns the "Did you mean" text naming the closest command or
+// flag to the unknown one being handled by CommandNotFound, OnUsageError or
+// the action, or an empty string if there is none
+func (c *Context) Suggestion() string {
+	return c.suggestion
+}
+
 // NumFlags returns the number of flags set
 func (c *Context) NumFlags() int {
 	return c.flagSet.NFlag()
@@ -142,7 +150,7 @@ func lookupFlag(name string, ctx *Context) Flag {
 			continue
 		}
 
-		for _, f := range c.Command.Flags {
+		for _, f := range c.Command.allFlags() {
 			for _, n := range f.Names() {
 				if n == name {
 					return f
@@ -152,7 +160,7 @@ func lookupFlag(name string, ctx *Context) Flag {
 	}
 
 	if ctx.App != nil {
-		for _, f := range ctx.App.Flags {
+		for _, f := range ctx.App.allFlags() {
 			for _, n := range f.Names() {
 				if n == name {
 					return f
@@ -164,6 +172,91 @@ func lookupFlag(name string, ctx *Context) Flag {
 	return nil
 }
 
+// inheritedFlags returns the persistent flags of the apps in the lineage of
+// ctx, closest first. Flags sharing a name with a local flag or with a
+// closer persistent flag are left out.
+func inheritedFlags(ctx *Context, local []Flag) []Flag {
+	seen := make(map[string]bool)
+	for _, f := range local {
+		for _, name := range f.Names() {
+			seen[name] = true
+		}
+	}
+
+	var inherited []Flag
+	var last *App
+	for _, c := range ctx.Lineage() {
+		if c.App == nil || c.App == last {
+			continue
+		}
+		last = c.App
+
+	persistent:
+		for _, f := range c.App.PersistentFlags {
+			for _, name := range f.Names() {
+				if seen[name] {
+					continue persistent
+				}
+			}
+			for _, name := range f.Names() {
+				seen[name] = true
+			}
+			inherited = append(inherited, f)
+		}
+	}
+
+	return inherited
+}
+
+// inheritFlagValues copies into set the values of the inherited flags that
+// were given before the subcommand name rather than after it. Slice and map
+// flags share their value across the levels, so the values given before and
+// after the subcommand name add up.
+func inheritFlagValues(flags []Flag, set *flag.FlagSet, ctx *Context) {
+	visited := make(map[string]bool)
+	set.Visit(func(f *flag.Flag) {
+		visited[f.Name] = true
+	})
+
+	for _, f := range flags {
+		names := f.Names()
+		var ff *flag.Flag
+		for _, name := range names {
+			if visited[name] {
+				ff = nil
+				break
+			}
+			if ff == nil {
+				ff = lookupSetFlag(name, ctx)
+			}
+		}
+		if ff == nil {
+			continue
+		}
+		for _, name := range names {
+			copyFlag(name, ff, set)
+		}
+	}
+}
+
+// lookupSetFlag returns the closest flag named name that was set in the
+// lineage of ctx
+func lookupSetFlag(name string, ctx *Context) *flag.Flag {
+	for _, c := range ctx.Lineage() {
+		var ff *flag.Flag
+		c.flagSet.Visit(func(f *flag.Flag) {
+			if f.Name == name {
+				ff = f
+			}
+		})
+		if ff != nil {
+			return ff
+		}
+	}
+
+	return nil
+}
+
 func lookupFlagSet(name string, ctx *Context) *flag.FlagSet {
 	for _, c := range ctx.Lineage() {
 		if f := c.flagSet.Lookup(name); f != nil {
@@ -193,6 +286,12 @@ func normalizeFlags(flags []Flag, set *flag.FlagSet) error {
 		if len(parts) == 1 {
 			continue
 		}
+		// the names of a count flag share one value, their occurrences add up
+		if ff := set.Lookup(parts[0]); ff != nil {
+			if _, ok := ff.Value.(*countValue); ok {
+				continue
+			}
+		}
 		var ff *flag.Flag
 		for _, name := range parts {
 			name = strings.Trim(name, " ")
@@ -84,7 +84,7 @@ func prepareCommands(commands []*Command, level int) []string {
 			usage,
 		)
 
-		flags := prepareArgsWithValues(command.Flags)
+		flags := prepareArgsWithValues(command.localFlags())
 		if len(flags) > 0 {
 			prepared += fmt.Sprintf("\n%s", strings.Join(flags, "\n"))
 		}
@@ -121,7 +121,8 @@ func prepareFlags(
 		}
 		modifiedArg := opener
 
-		for _, s := range flag.Names() {
+		names := append(flag.Names(), flagNegatedNames(flag)...)
+		for _, s := range names {
 			trimmed := strings.TrimSpace(s)
 			if len(modifiedArg) > len(opener) {
 				modifiedArg += sep
@@ -152,7 +153,7 @@ func prepareFlags(
 func flagDetails(flag DocGenerationFlag) string {
 	description := flag.GetUsage()
 	value := flag.GetValue()
-	if value != "" {
+	if value != "" && !isSensitive(flag) {
 		description += " (default: " + value + ")"
 	}
 	return ": " + description
@@ -110,6 +110,38 @@ func TestToMarkdownNoFlags(t *testing.T) {
 	expectFileContent(t, "testdata/expected-doc-no-flags.md", res)
 }
 
+func TestToMarkdownSensitiveFlag(t *testing.T) {
+	// Given
+	app := testApp()
+	app.Flags = []Flag{
+		&StringFlag{Name: "token", Usage: "api token", Value: "s3cr3t", Sensitive: true},
+	}
+
+	// When
+	res, err := app.ToMarkdown()
+
+	// Then
+	expect(t, err, nil)
+	expect(t, bytes.Contains([]byte(res), []byte("**--token**=\"\": api token\n")), true)
+	expect(t, bytes.Contains([]byte(res), []byte("s3cr3t")), false)
+}
+
+func TestToMarkdownNegatableFlag(t *testing.T) {
+	// Given
+	app := testApp()
+	app.Flags = []Flag{
+		&BoolFlag{Name: "color", Usage: "colorize output", Negatable: true},
+	}
+
+	// When
+	res, err := app.ToMarkdown()
+
+	// Then
+	expect(t, err, nil)
+	expect(t, bytes.Contains([]byte(res), []byte("**--color, --no-color**: colorize output\n")), true)
+	expect(t, bytes.Contains([]byte(res), []byte("[--color|--no-color]")), true)
+}
+
 func TestToMarkdownNoCommands(t *testing.T) {
 	// Given
 	app := testApp()
@@ -68,7 +68,7 @@ func (a *App) writeFishCompletionTemplate(w io.Writer) error {
 	// Add commands and their flags
 	completions = append(
 		completions,
-		a.prepareFishCommands(a.VisibleCommands(), &allCommands, []string{})...,
+		a.prepareFishCommands(a.VisibleCommands(), &allCommands, []string{}, a.PersistentFlags)...,
 	)
 
 	return t.ExecuteTemplate(w, name, &fishCompletionTemplate{
@@ -78,7 +78,7 @@ func (a *App) writeFishCompletionTemplate(w io.Writer) error {
 	})
 }
 
-func (a *App) prepareFishCommands(commands []*Command, allCommands *[]string, previousCommands []string) []string {
+func (a *App) prepareFishCommands(commands []*Command, allCommands *[]string, previousCommands []string, inherited []Flag) []string {
 	completions := []string{}
 	for _, command := range commands {
 		if command.Hidden {
@@ -112,12 +112,21 @@ func (a *App) prepareFishCommands(commands []*Command, allCommands *[]string, pr
 			a.prepareFishFlags(command.Flags, command.Names())...,
 		)
 
+		// persistent flags are accepted by the command and its subcommands
+		persistent := make([]Flag, 0, len(command.PersistentFlags)+len(inherited))
+		persistent = append(append(persistent, command.PersistentFlags...), inherited...)
+		completions = append(
+			completions,
+			a.prepareFishFlags(persistent, command.Names())...,
+		)
+
 		// recursevly iterate subcommands
 		if len(command.Subcommands) > 0 {
 			completions = append(
 				completions,
 				a.prepareFishCommands(
 					command.Subcommands, allCommands, command.Names(),
+					persistent,
 				)...,
 			)
 		}
@@ -156,6 +165,10 @@ func (a *App) prepareFishFlags(flags []Flag, previousCommands []string) []string
 			}
 		}
 
+		for _, opt := range flagNegatedNames(f) {
+			completion.WriteString(fmt.Sprintf(" -l %s", opt))
+		}
+
 		if flag.TakesValue() {
 			completion.WriteString(" -r")
 		}
@@ -172,19 +185,8 @@ func (a *App) prepareFishFlags(flags []Flag, previousCommands []string) []string
 }
 
 func fishAddFileFlag(flag Flag, completion *strings.Builder) {
-	switch f := flag.(type) {
-	case *GenericFlag:
-		if f.TakesFile {
-			return
-		}
-	case *StringFlag:
-		if f.TakesFile {
-			return
-		}
-	case *StringSliceFlag:
-		if f.TakesFile {
-			return
-		}
+	if takesFile(flag) {
+		return
 	}
 	completion.WriteString(" -f")
 }
@@ -15,6 +15,8 @@
 package cli
 
 import (
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io/ioutil"
@@ -33,6 +35,9 @@ var (
 	commaWhitespace = regexp.MustCompile("[, ]+.*")
 )
 
+// redactedValue stands in for the value of a sensitive flag in errors
+const redactedValue = "[REDACTED]"
+
 // BashCompletionFlag enables bash-completion for all commands and subcommands
 var BashCompletionFlag Flag = &BoolFlag{
 	Name:   "generate-bash-completion",
@@ -115,6 +120,24 @@ type RequiredFlag interface {
 	IsRequired() bool
 }
 
+// SensitiveFlag is an interface that allows us to mark flags whose values
+// must never be displayed, e.g. passwords and tokens
+type SensitiveFlag interface {
+	Flag
+
+	IsSensitive() bool
+}
+
+// NegatableFlag is an interface that allows us to mark bool flags which can
+// also be turned off with a --no-<name> form
+type NegatableFlag interface {
+	Flag
+
+	// NegatedNames returns the --no-<name> forms of the flag names, or nil
+	// if the flag is not negatable
+	NegatedNames() []string
+}
+
 // DocGenerationFlag is an interface that allows documentation generation for the flag
 type DocGenerationFlag interface {
 	Flag
@@ -131,10 +154,15 @@ type DocGenerationFlag interface {
 }
 
 func flagSet(name string, flags []Flag) (*flag.FlagSet, error) {
-	set := flag.NewFlagSet(name, flag.ContinueOnError)
+	return flagSetLenient(name, flags, false)
+}
 
+// flagSetLenient applies flags to a new flag set like flagSet, the bool flags
+// being lenient with lenientBools
+func flagSetLenient(name string, flags []Flag, lenientBools bool) (*flag.FlagSet, error) {
+	set := flag.NewFlagSet(name, flag.ContinueOnError)
 	for _, f := range flags {
-		if err := f.Apply(set); err != nil {
+		if err := applyFlag(f, set, lenientBools); err != nil {
 			return nil, err
 		}
 	}
@@ -142,17 +170,31 @@ func flagSet(name string, flags []Flag) (*flag.FlagSet, error) {
 	return set, nil
 }
 
+// applyFlag applies f to set, a bool flag being lenient for the time of its
+// Apply with lenientBools
+func applyFlag(f Flag, set *flag.FlagSet, lenientBools bool) error {
+	if lf, ok := f.(lenientFlag); ok && lenientBools {
+		lf.setAppLenient(true)
+		defer lf.setAppLenient(false)
+	}
+	return f.Apply(set)
+}
+
 func visibleFlags(fl []Flag) []Flag {
 	var visible []Flag
 	for _, f := range fl {
-		field := flagValue(f).FieldByName("Hidden")
-		if !field.IsValid() || !field.Bool() {
+		if !isHidden(f) {
 			visible = append(visible, f)
 		}
 	}
 	return visible
 }
 
+func isHidden(f Flag) bool {
+	field := flagValue(f).FieldByName("Hidden")
+	return field.IsValid() && field.Bool()
+}
+
 func prefixFor(name string) (prefix string) {
 	if len(name) == 1 {
 		prefix = "-"
@@ -265,9 +307,45 @@ func stringifyFlag(f Flag) string {
 	case *Float64SliceFlag:
 		return withEnvHint(flagStringSliceField(f, "EnvVars"),
 			stringifyFloat64SliceFlag(f))
+	case *UintSliceFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifyUintSliceFlag(f))
+	case *Uint64SliceFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifyUint64SliceFlag(f))
+	case *DurationSliceFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifyDurationSliceFlag(f))
+	case *TimestampSliceFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifyTimestampSliceFlag(f))
 	case *StringSliceFlag:
 		return withEnvHint(flagStringSliceField(f, "EnvVars"),
 			stringifyStringSliceFlag(f))
+	case *PathSliceFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifyPathSliceFlag(f))
+	case *OptionalBoolFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifyOptionalBoolFlag(f))
+	case *StringMapFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifyStringMapFlag(f))
+	case *GenericMapFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifyGenericMapFlag(f))
+	case *IPSliceFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifyNetSliceFlag(f, f.Usage, f.DefaultText, f.Value.strings()))
+	case *IPNetSliceFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifyNetSliceFlag(f, f.Usage, f.DefaultText, f.Value.strings()))
+	case *HostPortSliceFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifyNetSliceFlag(f, f.Usage, f.DefaultText, f.Value.strings()))
+	case *URLSliceFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifyNetSliceFlag(f, f.Usage, f.DefaultText, f.Value.redactedStrings()))
 	}
 
 	placeholder, usage := unquoteUsage(fv.FieldByName("Usage").String())
@@ -284,12 +362,54 @@ func stringifyFlag(f Flag) string {
 		}
 	}
 
+	if isSensitive(f) {
+		defaultValueString = ""
+	}
+
 	helpText := fv.FieldByName("DefaultText")
 	if helpText.IsValid() && helpText.String() != "" {
 		needsPlaceholder = val.Kind() != reflect.Bool
 		defaultValueString = fmt.Sprintf(" (default: %s)", helpText.String())
 	}
 
+	if cf, ok := f.(*CountFlag); ok {
+		needsPlaceholder = false
+		if cf.Value == 0 && cf.DefaultText == "" {
+			defaultValueString = ""
+		}
+	}
+
+	if bf, ok := f.(*ByteSizeFlag); ok {
+		if placeholder == "" {
+			placeholder = "size"
+		}
+		if bf.DefaultText == "" {
+			defaultValueString = fmt.Sprintf(" (default: %s)", FormatByteSize(bf.Value))
+		}
+	}
+
+	if tf, ok := f.(*TimestampFlag); ok {
+		if placeholder == "" {
+			placeholder = "time"
+		}
+		if tf.DefaultText == "" {
+			defaultValueString = fmt.Sprintf(" (default: %s)", tf.GetValue())
+		}
+	}
+
+	if p := netFlagPlaceholder(f); p != "" {
+		if placeholder == "" {
+			placeholder = p
+		}
+		if (!helpText.IsValid() || helpText.String() == "") && !isSensitive(f) {
+			defaultValueString = fmt.Sprintf(" (default: %s)", f.(DocGenerationFlag).GetValue())
+		}
+	}
+
+	if _, ok := f.(fileFlag); ok && placeholder == "" {
+		placeholder = "file"
+	}
+
 	if defaultValueString == " (default: )" {
 		defaultValueString = ""
 	}
@@ -301,12 +421,12 @@ func stringifyFlag(f Flag) string {
 	usageWithDefault := strings.TrimSpace(usage + defaultValueString)
 
 	return withEnvHint(flagStringSliceField(f, "EnvVars"),
-		fmt.Sprintf("%s\t%s", prefixedNames(f.Names(), placeholder), usageWithDefault))
+		fmt.Sprintf("%s\t%s", prefixedNames(helpNames(f), placeholder), usageWithDefault))
 }
 
 func stringifyIntSliceFlag(f *IntSliceFlag) string {
 	var defaultVals []string
-	if f.Value != nil && len(f.Value.Value()) > 0 {
+	if !f.Sensitive && f.Value != nil && len(f.Value.Value()) > 0 {
 		for _, i := range f.Value.Value() {
 			defaultVals = append(defaultVals, strconv.Itoa(i))
 		}
@@ -317,7 +437,7 @@ func stringifyIntSliceFlag(f *IntSliceFlag) string {
 
 func stringifyInt64SliceFlag(f *Int64SliceFlag) string {
 	var defaultVals []string
-	if f.Value != nil && len(f.Value.Value()) > 0 {
+	if !f.Sensitive && f.Value != nil && len(f.Value.Value()) > 0 {
 		for _, i := range f.Value.Value() {
 			defaultVals = append(defaultVals, strconv.FormatInt(i, 10))
 		}
@@ -329,7 +449,7 @@ func stringifyInt64SliceFlag(f *Int64SliceFlag) string {
 func stringifyFloat64SliceFlag(f *Float64SliceFlag) string {
 	var defaultVals []string
 
-	if f.Value != nil && len(f.Value.Value()) > 0 {
+	if !f.Sensitive && f.Value != nil && len(f.Value.Value()) > 0 {
 		for _, i := range f.Value.Value() {
 			defaultVals = append(defaultVals, strings.TrimSuffix(strings.TrimSuffix(fmt.Sprintf("%f", i), "0"), "."))
 		}
@@ -338,9 +458,54 @@ func stringifyFloat64SliceFlag(f *Float64SliceFlag) string {
 	return stringifySliceFlag(f.Usage, "[]float64", f.Names(), defaultVals)
 }
 
+func stringifyUintSliceFlag(f *UintSliceFlag) string {
+	var defaultVals []string
+	if !f.Sensitive && f.Value != nil && len(f.Value.Value()) > 0 {
+		for _, i := range f.Value.Value() {
+			defaultVals = append(defaultVals, strconv.FormatUint(uint64(i), 10))
+		}
+	}
+
+	return stringifySliceFlag(f.Usage, "[]uint", f.Names(), defaultVals)
+}
+
+func stringifyUint64SliceFlag(f *Uint64SliceFlag) string {
+	var defaultVals []string
+	if !f.Sensitive && f.Value != nil && len(f.Value.Value()) > 0 {
+		for _, i := range f.Value.Value() {
+			defaultVals = append(defaultVals, strconv.FormatUint(i, 10))
+		}
+	}
+
+	return stringifySliceFlag(f.Usage, "[]uint64", f.Names(), defaultVals)
+}
+
+func stringifyDurationSliceFlag(f *DurationSliceFlag) string {
+	var defaultVals []string
+	if !f.Sensitive && f.Value != nil && len(f.Value.Value()) > 0 {
+		for _, d := range f.Value.Value() {
+			defaultVals = append(defaultVals, d.String())
+		}
+	}
+
+	return stringifySliceFlag(f.Usage, "[]duration", f.Names(), defaultVals)
+}
+
+func stringifyTimestampSliceFlag(f *TimestampSliceFlag) string {
+	var defaultVals []string
+	if !f.Sensitive && f.Value != nil {
+		f.Value.SetLayout(f.Layout)
+		for _, s := range f.Value.strings() {
+			defaultVals = append(defaultVals, strconv.Quote(s))
+		}
+	}
+
+	return stringifySliceFlag(f.Usage, "[]timestamp", f.Names(), defaultVals)
+}
+
 func stringifyStringSliceFlag(f *StringSliceFlag) string {
 	var defaultVals []string
-	if f.Value != nil && len(f.Value.Value()) > 0 {
+	if !f.Sensitive && f.Value != nil && len(f.Value.Value()) > 0 {
 		for _, s := range f.Value.Value() {
 			if len(s) > 0 {
 				defaultVals = append(defaultVals, strconv.Quote(s))
@@ -351,6 +516,212 @@ func stringifyStringSliceFlag(f *StringSliceFlag) string {
 	return stringifySliceFlag(f.Usage, "strings", f.Names(), defaultVals)
 }
 
+func stringifyPathSliceFlag(f *PathSliceFlag) string {
+	var defaultVals []string
+	if f.DefaultText != "" {
+		defaultVals = []string{f.DefaultText}
+	} else if !f.Sensitive {
+		for _, s := range f.Value.strings() {
+			defaultVals = append(defaultVals, strconv.Quote(s))
+		}
+	}
+
+	return stringifySliceFlag(f.Usage, "paths", f.Names(), defaultVals)
+}
+
+func stringifyStringMapFlag(f *StringMapFlag) string {
+	var defaultVals []string
+	if !f.Sensitive && f.Value != nil {
+		for _, pair := range stringMapPairs(f.Value.Value()) {
+			defaultVals = append(defaultVals, strconv.Quote(pair))
+		}
+	}
+
+	return stringifyMapFlag(f.Usage, f.DefaultText, f.Names(), defaultVals)
+}
+
+func stringifyGenericMapFlag(f *GenericMapFlag) string {
+	var defaultVals []string
+	if !f.Sensitive && f.Value != nil {
+		for _, pair := range stringMapPairs(f.Value.stringMap()) {
+			defaultVals = append(defaultVals, strconv.Quote(pair))
+		}
+	}
+
+	return stringifyMapFlag(f.Usage, f.DefaultText, f.Names(), defaultVals)
+}
+
+func stringifyMapFlag(usage, defaultText string, names, defaultVals []string) string {
+	if defaultText != "" {
+		defaultVals = []string{defaultText}
+	}
+	return stringifySliceFlag(usage, "key=value", names, defaultVals)
+}
+
+func stringifyNetSliceFlag(f Flag, usage, defaultText string, defaultVals []string) string {
+	if isSensitive(f) {
+		defaultVals = nil
+	}
+	if defaultText != "" {
+		defaultVals = []string{defaultText}
+	}
+	return stringifySliceFlag(usage, netFlagPlaceholder(f), f.Names(), defaultVals)
+}
+
+// netFlagPlaceholder returns the help placeholder of the network typed flags
+// and an empty string for any other flag
+func netFlagPlaceholder(f Flag) string {
+	switch f.(type) {
+	case *IPFlag, *IPSliceFlag:
+		return "ip"
+	case *IPNetFlag, *IPNetSliceFlag:
+		return "cidr"
+	case *HostPortFlag, *HostPortSliceFlag:
+		return "host:port"
+	case *URLFlag, *URLSliceFlag:
+		return "url"
+	}
+	return ""
+}
+
+// fileFlag is implemented by the flags whose value is always a file path
+type fileFlag interface {
+	isFileFlag()
+}
+
+// takesFile returns whether the value of the flag is a file path, either
+// because of its type or because of a TakesFile field set to true, so that
+// shell completion offers file names
+func takesFile(f Flag) bool {
+	if _, ok := f.(fileFlag); ok {
+		return true
+	}
+	fv := flagValue(f)
+	if fv.Kind() != reflect.Struct {
+		return false
+	}
+	field := fv.FieldByName("TakesFile")
+	return field.IsValid() && field.Kind() == reflect.Bool && field.Bool()
+}
+
+// fileCloser is implemented by the flag values holding files opened while
+// running an action
+type fileCloser interface {
+	closeFile(commit bool) error
+}
+
+// closeFileFlags closes the files opened through the flags of set, once the
+// action and the After hook are done, adding the errors to *err. Atomic output
+// files replace their path only if the action succeeded.
+func closeFileFlags(set *flag.FlagSet, err *error) {
+	if set == nil {
+		return
+	}
+
+	var errs []error
+	if *err != nil {
+		errs = append(errs, *err)
+	}
+	set.VisitAll(func(f *flag.Flag) {
+		if fc, ok := f.Value.(fileCloser); ok {
+			if closeErr := fc.closeFile(*err == nil); closeErr != nil {
+				errs = append(errs, closeErr)
+			}
+		}
+	})
+
+	switch len(errs) {
+	case 0:
+	case 1:
+		*err = errs[0]
+	default:
+		*err = newMultiError(errs...)
+	}
+}
+
+// sliceSeparator splits the values given to slice flags in one argument,
+// environment variable or file. The zero value splits on commas.
+type sliceSeparator struct {
+	separator    string
+	disableSplit bool
+}
+
+// sliceSeparators returns how a slice flag splits the values given on the
+// command line and those given through EnvVars or FilePath. Values are split
+// on separator, a comma when empty, unless disableSplit is set. When not
+// empty, envSeparator splits the values of EnvVars and FilePath instead.
+func sliceSeparators(separator string, disableSplit bool, envSeparator string) (flagSep, envSep sliceSeparator) {
+	flagSep = sliceSeparator{separator: separator, disableSplit: disableSplit}
+	envSep = flagSep
+	if envSeparator != "" {
+		envSep = sliceSeparator{separator: envSeparator}
+	}
+	return flagSep, envSep
+}
+
+// split returns the values of val. With splitting disabled, val is a single
+// value kept as is.
+func (s sliceSeparator) split(val string) []string {
+	if s.disableSplit {
+		return []string{val}
+	}
+
+	val = strings.TrimSpace(val)
+	if val == "" {
+		return nil
+	}
+
+	sep := s.separator
+	if sep == "" {
+		sep = ","
+	}
+	values := strings.Split(val, sep)
+	for i, v := range values {
+		values[i] = strings.TrimSpace(v)
+	}
+	return values
+}
+
+// splitList is like split, but strips the brackets of a comma separated
+// list as printed by the String method of slices
+func (s sliceSeparator) splitList(val string) []string {
+	if s.separator == "" && !s.disableSplit {
+		val = strings.Trim(val, "[]")
+	}
+	return s.split(val)
+}
+
+// splitValue splits the values given to a slice flag. A serialized slice is
+// decoded as a whole and replaces the current values.
+func (s sliceSeparator) splitValue(value string) (values []string, overwrite bool) {
+	if strings.HasPrefix(value, slPfx) {
+		_ = json.Unmarshal([]byte(strings.Replace(value, slPfx, "", 1)), &values)
+		return values, true
+	}
+	return s.split(value), false
+}
+
+// serializeSlice serializes values so that splitValue decodes them
+// unchanged, even when they contain commas
+func serializeSlice(values []string) string {
+	jsonBytes, _ := json.Marshal(values)
+	return fmt.Sprintf("%s%s", slPfx, string(jsonBytes))
+}
+
+func stringifyOptionalBoolFlag(f *OptionalBoolFlag) string {
+	_, usage := unquoteUsage(f.Usage)
+
+	defaultVal := ""
+	if f.DefaultText != "" {
+		defaultVal = fmt.Sprintf(" (default: %s)", f.DefaultText)
+	} else if f.Value != nil {
+		defaultVal = fmt.Sprintf(" (default: %t)", *f.Value)
+	}
+
+	usageWithDefault := strings.TrimSpace(usage + defaultVal)
+	return fmt.Sprintf("%s\t%s", prefixedNames(helpNames(f), ""), usageWithDefault)
+}
+
 func stringifySliceFlag(usage, defaultPlaceholder string, names, defaultVals []string) string {
 	placeholder, usage := unquoteUsage(usage)
 	if placeholder == "" {
@@ -366,6 +737,72 @@ func stringifySliceFlag(usage, defaultPlaceholder string, names, defaultVals []s
 	return fmt.Sprintf("%s\t%s", prefixedNames(names, placeholder), usageWithDefault)
 }
 
+func flagNegatedNames(f Flag) []string {
+	if nf, ok := f.(NegatableFlag); ok {
+		return nf.NegatedNames()
+	}
+	return nil
+}
+
+// helpNames returns the names of f as shown in help, with the long names of
+// negatable flags written as [no-]name
+func helpNames(f Flag) []string {
+	names := f.Names()
+	if len(flagNegatedNames(f)) == 0 {
+		return names
+	}
+
+	shown := make([]string, len(names))
+	for i, name := range names {
+		if len(name) > 1 {
+			name = "[no-]" + name
+		}
+		shown[i] = name
+	}
+	return shown
+}
+
+func isSensitive(f Flag) bool {
+	sf, ok := f.(SensitiveFlag)
+	return ok && sf.IsSensitive()
+}
+
+// errorValue returns the value to show in the errors of f, redactedValue
+// when f is a sensitive flag
+func errorValue(f Flag, val string) string {
+	if isSensitive(f) {
+		return redactedValue
+	}
+	return val
+}
+
+// redactError replaces the quoted vals with the quoted redactedValue in the
+// message of err when f is a sensitive flag. Only quoted values are replaced,
+// so that a short value does not garble the rest of the message.
+func redactError(f Flag, err error, vals ...string) error {
+	if err == nil || !isSensitive(f) {
+		return err
+	}
+	msg := err.Error()
+	for _, val := range vals {
+		if val != "" {
+			msg = strings.Replace(msg, strconv.Quote(val), strconv.Quote(redactedValue), -1)
+		}
+	}
+	return errors.New(msg)
+}
+
+// RedactFlagError hides the quoted vals and the URLs among them from the
+// message of err when f is a sensitive flag, like the errors the flag reports
+// itself. Input sources use it for the errors of the values they set.
+func RedactFlagError(f Flag, err error, vals ...string) error {
+	redacted := make([]string, 0, 2*len(vals))
+	for _, val := range vals {
+		redacted = append(redacted, val, redactURLs(val))
+	}
+	return redactError(f, err, redacted...)
+}
+
 func hasFlag(flags []Flag, fl Flag) bool {
 	for _, existing := range flags {
 		if fl == existing {
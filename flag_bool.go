@@ -18,9 +18,88 @@ import (
 	"flag"
 	"fmt"
 	"strconv"
+	"strings"
 )
 
-// BoolFlag is a flag with type bool
+// lenientBools maps the lowercase spellings accepted by lenient bool flags
+// to their value
+var lenientBools = map[string]bool{
+	"1":        true,
+	"t":        true,
+	"true":     true,
+	"y":        true,
+	"yes":      true,
+	"on":       true,
+	"enabled":  true,
+	"0":        false,
+	"f":        false,
+	"false":    false,
+	"n":        false,
+	"no":       false,
+	"off":      false,
+	"disabled": false,
+}
+
+// parseBool parses s like strconv.ParseBool or, when lenient, accepts the
+// spellings of lenientBools in any case too. Errors list the accepted
+// spellings.
+func parseBool(s string, lenient bool) (bool, error) {
+	if !lenient {
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return false, fmt.Errorf("invalid boolean %q, expected one of 1, t, T, TRUE, true, True, 0, f, F, FALSE, false or False", s)
+		}
+		return b, nil
+	}
+
+	if b, ok := lenientBools[strings.ToLower(strings.TrimSpace(s))]; ok {
+		return b, nil
+	}
+	return false, fmt.Errorf("invalid boolean %q, expected one of true, false, yes, no, y, n, on, off, enabled, disabled, 1 or 0 in any case", s)
+}
+
+// lenientBoolValue is a flag.Value parsing bools in lenient mode
+type lenientBoolValue struct {
+	value *bool
+}
+
+func newLenientBoolValue(val bool, p *bool) *lenientBoolValue {
+	*p = val
+	return &lenientBoolValue{value: p}
+}
+
+func (b *lenientBoolValue) Set(s string) error {
+	v, err := parseBool(s, true)
+	if err != nil {
+		return err
+	}
+	*b.value = v
+	return nil
+}
+
+func (b *lenientBoolValue) Get() interface{} { return *b.value }
+
+func (b *lenientBoolValue) String() string {
+	if b.value == nil {
+		return "false"
+	}
+	return strconv.FormatBool(*b.value)
+}
+
+// IsBoolFlag allows the flag to be given without a value
+func (b *lenientBoolValue) IsBoolFlag() bool {
+	return true
+}
+
+// lenientFlag is implemented by the bool flags, which an app with
+// LenientBools applies in lenient mode
+type lenientFlag interface {
+	setAppLenient(lenient bool)
+}
+
+// BoolFlag is a flag with type bool. With Lenient, the values given on the
+// command line, through EnvVars, FilePath or an input source may be spelled
+// yes/no, y/n, on/off or enabled/disabled as well, in any case.
 type BoolFlag struct {
 	Name        string
 	Aliases     []string
@@ -29,10 +108,20 @@ type BoolFlag struct {
 	FilePath    string
 	Required    bool
 	Hidden      bool
+	Negatable   bool
+	Lenient     bool
 	Value       bool
 	DefaultText string
 	Destination *bool
 	HasBeenSet  bool
+
+	appLenient bool
+}
+
+// setAppLenient makes the flag lenient while it is applied for an app with
+// LenientBools
+func (f *BoolFlag) setAppLenient(lenient bool) {
+	f.appLenient = lenient
 }
 
 // IsSet returns whether or not the flag has been set through env or file
@@ -56,6 +145,15 @@ func (f *BoolFlag) IsRequired() bool {
 	return f.Required
 }
 
+// NegatedNames returns the --no-<name> forms of the long flag names when the
+// flag is negatable
+func (f *BoolFlag) NegatedNames() []string {
+	if !f.Negatable {
+		return nil
+	}
+	return negatedNames(f.Names())
+}
+
 // TakesValue returns true of the flag takes a value, otherwise false
 func (f *BoolFlag) TakesValue() bool {
 	return false
@@ -74,10 +172,10 @@ func (f *BoolFlag) GetValue() string {
 
 // Apply populates the flag given the flag set and environment
 func (f *BoolFlag) Apply(set *flag.FlagSet) error {
+	lenient := f.Lenient || f.appLenient
 	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
 		if val != "" {
-			valBool, err := strconv.ParseBool(val)
-
+			valBool, err := parseBool(val, lenient)
 			if err != nil {
 				return fmt.Errorf("could not parse %q as bool value for flag %s: %s", val, f.Name, err)
 			}
@@ -88,6 +186,14 @@ func (f *BoolFlag) Apply(set *flag.FlagSet) error {
 	}
 
 	for _, name := range f.Names() {
+		if lenient {
+			dest := f.Destination
+			if dest == nil {
+				dest = new(bool)
+			}
+			set.Var(newLenientBoolValue(f.Value, dest), name, f.Usage)
+			continue
+		}
 		if f.Destination != nil {
 			set.BoolVar(f.Destination, name, f.Value, f.Usage)
 			continue
@@ -95,9 +201,56 @@ func (f *BoolFlag) Apply(set *flag.FlagSet) error {
 		set.Bool(name, f.Value, f.Usage)
 	}
 
+	if f.Negatable {
+		applyNegation(set, f.Names(), f.Usage, lenient)
+	}
+
 	return nil
 }
 
+// boolNegation is the value of the --no-<name> form of a negatable flag. It
+// sets the flag it negates to the opposite of its own value.
+type boolNegation struct {
+	set     *flag.FlagSet
+	name    string
+	lenient bool
+}
+
+func (b *boolNegation) Set(value string) error {
+	v, err := parseBool(value, b.lenient)
+	if err != nil {
+		return err
+	}
+	return b.set.Set(b.name, strconv.FormatBool(!v))
+}
+
+func (b *boolNegation) String() string {
+	return ""
+}
+
+func (b *boolNegation) IsBoolFlag() bool {
+	return true
+}
+
+func negatedNames(names []string) []string {
+	var negated []string
+	for _, name := range names {
+		if len(name) > 1 {
+			negated = append(negated, "no-"+name)
+		}
+	}
+	return negated
+}
+
+// applyNegation defines the --no-<name> forms of the long names on set
+func applyNegation(set *flag.FlagSet, names []string, usage string, lenient bool) {
+	for _, name := range names {
+		if len(name) > 1 {
+			set.Var(&boolNegation{set: set, name: name, lenient: lenient}, "no-"+name, usage)
+		}
+	}
+}
+
 func (a *App) boolVar(p *bool, name, alias string, value bool, usage, env string) {
 	if a.Flags == nil {
 		a.Flags = make([]Flag, 0)
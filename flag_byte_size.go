@@ -0,0 +1,289 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// byteSizeUnits maps the units of a byte size to their number of bytes.
+// SI units (kB, MB, ...) are powers of 1000 and IEC units (KiB, MiB, ...)
+// powers of 1024. The single letters K, M, G, ... are SI units too, as are
+// the suffixes of integer flags with SISuffixes.
+var byteSizeUnits = map[string]uint64{
+	"":  1,
+	"b": 1,
+
+	"kb": 1e3,
+	"mb": 1e6,
+	"gb": 1e9,
+	"tb": 1e12,
+	"pb": 1e15,
+	"eb": 1e18,
+
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+	"pib": 1 << 50,
+	"eib": 1 << 60,
+
+	"k": 1e3,
+	"m": 1e6,
+	"g": 1e9,
+	"t": 1e12,
+	"p": 1e15,
+	"e": 1e18,
+}
+
+// ParseByteSize parses a number of bytes with an optional unit, e.g. 512,
+// 64KiB, 1.5GB or 10M. Units are case insensitive: kB, MB, GB, TB, PB and EB
+// as well as the single letters K, M, G, T, P and E are powers of 1000, like
+// the SI suffixes of integer flags, while KiB, MiB, GiB, TiB, PiB and EiB are
+// powers of 1024. A fractional size must amount to a whole number of bytes.
+func ParseByteSize(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+
+	number, unit := s[:i], strings.ToLower(strings.TrimSpace(s[i:]))
+	multiplier, ok := byteSizeUnits[unit]
+	if number == "" || !ok {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+
+	if !strings.Contains(number, ".") {
+		n, err := strconv.ParseUint(number, 10, 64)
+		if err != nil || n > math.MaxUint64/multiplier {
+			return 0, fmt.Errorf("byte size %q out of range", s)
+		}
+		return n * multiplier, nil
+	}
+
+	// decimal fractions are exact as rationals, unlike floats
+	r, ok := new(big.Rat).SetString(number)
+	if !ok {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+	r.Mul(r, new(big.Rat).SetInt(new(big.Int).SetUint64(multiplier)))
+	if !r.IsInt() {
+		return 0, fmt.Errorf("byte size %q is not a whole number of bytes", s)
+	}
+	if !r.Num().IsUint64() {
+		return 0, fmt.Errorf("byte size %q out of range", s)
+	}
+	return r.Num().Uint64(), nil
+}
+
+// FormatByteSize formats a number of bytes with the largest IEC or SI unit
+// dividing it exactly, e.g. 64KiB or 1500MB, so that it parses back to the
+// same number
+func FormatByteSize(n uint64) string {
+	if n == 0 {
+		return "0B"
+	}
+
+	for _, u := range []string{"EiB", "PiB", "TiB", "GiB", "MiB", "KiB", "EB", "PB", "TB", "GB", "MB", "kB"} {
+		if m := byteSizeUnits[strings.ToLower(u)]; n%m == 0 {
+			return strconv.FormatUint(n/m, 10) + u
+		}
+	}
+	return strconv.FormatUint(n, 10) + "B"
+}
+
+// byteSizeValue is a flag.Value parsing byte sizes into a uint64
+type byteSizeValue uint64
+
+func newByteSizeValue(val uint64, p *uint64) *byteSizeValue {
+	*p = val
+	return (*byteSizeValue)(p)
+}
+
+func (b *byteSizeValue) Set(s string) error {
+	n, err := ParseByteSize(s)
+	if err != nil {
+		return err
+	}
+	*b = byteSizeValue(n)
+	return nil
+}
+
+func (b *byteSizeValue) Get() interface{} { return uint64(*b) }
+
+func (b *byteSizeValue) String() string { return FormatByteSize(uint64(*b)) }
+
+// ByteSizeFlag is a flag with type uint64 given as a byte size with an
+// optional unit, see ParseByteSize
+type ByteSizeFlag struct {
+	Name        string
+	Aliases     []string
+	Usage       string
+	EnvVars     []string
+	FilePath    string
+	Required    bool
+	Hidden      bool
+	Value       uint64
+	DefaultText string
+	Destination *uint64
+	HasBeenSet  bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *ByteSizeFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *ByteSizeFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *ByteSizeFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *ByteSizeFlag) IsRequired() bool {
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *ByteSizeFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *ByteSizeFlag) GetUsage() string {
+	return f.Usage
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *ByteSizeFlag) Apply(set *flag.FlagSet) error {
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		if val != "" {
+			valSize, err := ParseByteSize(val)
+			if err != nil {
+				return fmt.Errorf("could not parse %q as byte size value for flag %s: %s", val, f.Name, err)
+			}
+
+			f.Value = valSize
+			f.HasBeenSet = true
+		}
+	}
+
+	for _, name := range f.Names() {
+		if f.Destination != nil {
+			set.Var(newByteSizeValue(f.Value, f.Destination), name, f.Usage)
+			continue
+		}
+		set.Var(newByteSizeValue(f.Value, new(uint64)), name, f.Usage)
+	}
+
+	return nil
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *ByteSizeFlag) GetValue() string {
+	return FormatByteSize(f.Value)
+}
+
+func (a *App) byteSizeVar(p *uint64, name, alias string, value uint64, usage, env string) {
+	if a.Flags == nil {
+		a.Flags = make([]Flag, 0)
+	}
+	flag := &ByteSizeFlag{
+		Name:        name,
+		Usage:       usage,
+		Value:       value,
+		Destination: p,
+	}
+	if alias != "" {
+		flag.Aliases = []string{alias}
+	}
+	if env != "" {
+		flag.EnvVars = []string{env}
+	}
+	a.Flags = append(a.Flags, flag)
+}
+
+// ByteSizeVar defines a byte size flag with specified name, default value, usage string and env string.
+// The argument p points to a uint64 variable in which to store the number of bytes.
+func (a *App) ByteSizeVar(p *uint64, name string, value uint64, usage, env string) {
+	a.byteSizeVar(p, name, "", value, usage, env)
+}
+
+// ByteSizeVarP is like ByteSizeVar, but accepts a shorthand letter that can be used after a single dash.
+func (a *App) ByteSizeVarP(p *uint64, name, alias string, value uint64, usage, env string) {
+	a.byteSizeVar(p, name, alias, value, usage, env)
+}
+
+// ByteSizeVar defines a byte size flag with specified name, default value, usage string and env string.
+// The argument p points to a uint64 variable in which to store the number of bytes.
+func ByteSizeVar(p *uint64, name string, value uint64, usage, env string) {
+	CommandLine.ByteSizeVar(p, name, value, usage, env)
+}
+
+// ByteSizeVarP is like ByteSizeVar, but accepts a shorthand letter that can be used after a single dash.
+func ByteSizeVarP(p *uint64, name, alias string, value uint64, usage, env string) {
+	CommandLine.ByteSizeVarP(p, name, alias, value, usage, env)
+}
+
+// ByteSize defines a byte size flag with specified name, default value, usage string and env string.
+// The return value is the address of a uint64 variable that stores the number of bytes.
+func (a *App) ByteSize(name string, value uint64, usage, env string) *uint64 {
+	p := new(uint64)
+	a.ByteSizeVar(p, name, value, usage, env)
+	return p
+}
+
+// ByteSizeP is like ByteSize, but accepts a shorthand letter that can be used after a single dash.
+func (a *App) ByteSizeP(name, alias string, value uint64, usage, env string) *uint64 {
+	p := new(uint64)
+	a.ByteSizeVarP(p, name, alias, value, usage, env)
+	return p
+}
+
+// ByteSize looks up the value of a local ByteSizeFlag, returns
+// 0 if not found
+func (c *Context) ByteSize(name string) uint64 {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return lookupByteSize(name, fs)
+	}
+	return 0
+}
+
+func lookupByteSize(name string, set *flag.FlagSet) uint64 {
+	f := set.Lookup(name)
+	if f != nil {
+		parsed, err := ParseByteSize(f.Value.String())
+		if err != nil {
+			return 0
+		}
+		return parsed
+	}
+	return 0
+}
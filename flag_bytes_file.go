@@ -0,0 +1,162 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+// bytesFile is a flag.Value holding the path of a file read on first use
+type bytesFile struct {
+	path string
+	data []byte
+	read bool
+}
+
+func (b *bytesFile) Set(path string) error {
+	if err := checkInputPath(path); err != nil {
+		return err
+	}
+	b.path = path
+	b.data = nil
+	b.read = false
+	return nil
+}
+
+func (b *bytesFile) Get() interface{} { return b.path }
+
+func (b *bytesFile) String() string {
+	if b == nil {
+		return ""
+	}
+	return b.path
+}
+
+// bytes returns the content of the file, reading it once
+func (b *bytesFile) bytes() ([]byte, error) {
+	if b.read || b.path == "" {
+		return b.data, nil
+	}
+
+	var data []byte
+	var err error
+	if b.path == stdioPath {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(b.path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	b.data = data
+	b.read = true
+	return data, nil
+}
+
+// BytesFileFlag is a flag holding the path of a file whose whole content is
+// the value, "-" standing for stdin
+type BytesFileFlag struct {
+	Name        string
+	Aliases     []string
+	Usage       string
+	EnvVars     []string
+	FilePath    string
+	Required    bool
+	Hidden      bool
+	Value       string
+	DefaultText string
+	HasBeenSet  bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *BytesFileFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *BytesFileFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *BytesFileFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *BytesFileFlag) IsRequired() bool {
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *BytesFileFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *BytesFileFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *BytesFileFlag) GetValue() string {
+	return f.Value
+}
+
+// isFileFlag marks the value of the flag as a file path
+func (f *BytesFileFlag) isFileFlag() {}
+
+// Apply populates the flag given the flag set and environment
+func (f *BytesFileFlag) Apply(set *flag.FlagSet) error {
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		if err := checkInputPath(val); err != nil {
+			return fmt.Errorf("could not use %q as input file for flag %s: %s", val, f.Name, err)
+		}
+
+		f.Value = val
+		f.HasBeenSet = true
+	}
+
+	value := &bytesFile{path: f.Value}
+	for _, name := range f.Names() {
+		set.Var(value, name, f.Usage)
+	}
+
+	return nil
+}
+
+// BytesFile reads the file of a local BytesFileFlag, returns nil if not
+// found or if no file has been given
+func (c *Context) BytesFile(name string) ([]byte, error) {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return lookupBytesFile(name, fs)
+	}
+	return nil, nil
+}
+
+func lookupBytesFile(name string, set *flag.FlagSet) ([]byte, error) {
+	f := set.Lookup(name)
+	if f != nil {
+		if v, ok := f.Value.(*bytesFile); ok {
+			return v.bytes()
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,232 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+)
+
+// countValue counts the occurrences of a flag. It is shared by all the
+// names of the flag, so that -v -v and --verbose --verbose count alike.
+type countValue struct {
+	count      *int
+	hasBeenSet bool
+}
+
+func newCountValue(value int, p *int) *countValue {
+	if p == nil {
+		p = new(int)
+	}
+	*p = value
+	return &countValue{count: p}
+}
+
+// Set counts one more occurrence when the flag is given without a value and
+// takes the value as the count when it is a number, e.g. from the
+// environment. The first occurrence replaces the default value.
+func (c *countValue) Set(value string) error {
+	if !c.hasBeenSet {
+		*c.count = 0
+		c.hasBeenSet = true
+	}
+
+	if n, err := strconv.Atoi(value); err == nil {
+		if n < 0 {
+			return fmt.Errorf("count must not be negative: %d", n)
+		}
+		*c.count = n
+		return nil
+	}
+
+	occurred, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("expected a count or a bool, got %q", value)
+	}
+	if occurred {
+		*c.count++
+	} else {
+		*c.count = 0
+	}
+	return nil
+}
+
+func (c *countValue) String() string {
+	if c == nil || c.count == nil {
+		return "0"
+	}
+	return strconv.Itoa(*c.count)
+}
+
+func (c *countValue) Get() interface{} {
+	return *c.count
+}
+
+func (c *countValue) IsBoolFlag() bool {
+	return true
+}
+
+// CountFlag is a flag whose value is the number of times it is given,
+// e.g. -vvv
+type CountFlag struct {
+	Name        string
+	Aliases     []string
+	Usage       string
+	EnvVars     []string
+	FilePath    string
+	Required    bool
+	Hidden      bool
+	Value       int
+	DefaultText string
+	Destination *int
+	HasBeenSet  bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *CountFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *CountFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *CountFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *CountFlag) IsRequired() bool {
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *CountFlag) TakesValue() bool {
+	return false
+}
+
+// GetUsage returns the usage string for the flag
+func (f *CountFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *CountFlag) GetValue() string {
+	return ""
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *CountFlag) Apply(set *flag.FlagSet) error {
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		if val != "" {
+			valInt, err := strconv.Atoi(val)
+			if err != nil || valInt < 0 {
+				return fmt.Errorf("could not parse %q as count value for flag %s: expected a non-negative integer", val, f.Name)
+			}
+
+			f.Value = valInt
+			f.HasBeenSet = true
+		}
+	}
+
+	value := newCountValue(f.Value, f.Destination)
+	for _, name := range f.Names() {
+		set.Var(value, name, f.Usage)
+	}
+
+	return nil
+}
+
+func (a *App) countVar(p *int, name, alias string, value int, usage, env string) {
+	if a.Flags == nil {
+		a.Flags = make([]Flag, 0)
+	}
+	flag := &CountFlag{
+		Name:        name,
+		Usage:       usage,
+		Value:       value,
+		Destination: p,
+	}
+	if alias != "" {
+		flag.Aliases = []string{alias}
+	}
+	if env != "" {
+		flag.EnvVars = []string{env}
+	}
+	a.Flags = append(a.Flags, flag)
+}
+
+// CountVar defines a count flag with specified name, default value, usage string and env string.
+// The argument p points to a int variable in which to store the number of times the flag is given.
+func (a *App) CountVar(p *int, name string, value int, usage, env string) {
+	a.countVar(p, name, "", value, usage, env)
+}
+
+// CountVarP is like CountVar, but accepts a shorthand letter that can be used after a single dash.
+func (a *App) CountVarP(p *int, name, alias string, value int, usage, env string) {
+	a.countVar(p, name, alias, value, usage, env)
+}
+
+// CountVar defines a count flag with specified name, default value, usage string and env string.
+// The argument p points to a int variable in which to store the number of times the flag is given.
+func CountVar(p *int, name string, value int, usage, env string) {
+	CommandLine.CountVar(p, name, value, usage, env)
+}
+
+// CountVarP is like CountVar, but accepts a shorthand letter that can be used after a single dash.
+func CountVarP(p *int, name, alias string, value int, usage, env string) {
+	CommandLine.CountVarP(p, name, alias, value, usage, env)
+}
+
+// Count defines a count flag with specified name, default value, usage string and env string.
+// The return value is the address of a int variable that stores the number of times the flag is given.
+func (a *App) Count(name string, value int, usage, env string) *int {
+	p := new(int)
+	a.CountVar(p, name, value, usage, env)
+	return p
+}
+
+// CountP is like Count, but accepts a shorthand letter that can be used after a single dash.
+func (a *App) CountP(name, alias string, value int, usage, env string) *int {
+	p := new(int)
+	a.CountVarP(p, name, alias, value, usage, env)
+	return p
+}
+
+// Count looks up the value of a local CountFlag, returns
+// 0 if not found
+func (c *Context) Count(name string) int {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return lookupCount(name, fs)
+	}
+	return 0
+}
+
+func lookupCount(name string, set *flag.FlagSet) int {
+	f := set.Lookup(name)
+	if f != nil {
+		parsed, err := strconv.Atoi(f.Value.String())
+		if err != nil {
+			return 0
+		}
+		return parsed
+	}
+	return 0
+}
@@ -0,0 +1,277 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DurationSlice wraps []time.Duration to satisfy flag.Value
+type DurationSlice struct {
+	value      *[]time.Duration
+	hasBeenSet bool
+	sliceSeparator
+}
+
+// NewDurationSlice makes an *DurationSlice with default values
+func NewDurationSlice(defaults ...time.Duration) *DurationSlice {
+	return newDurationSlice(defaults, nil)
+}
+
+func newDurationSlice(value []time.Duration, p *[]time.Duration) *DurationSlice {
+	slice := new(DurationSlice)
+	if p == nil {
+		p = &[]time.Duration{}
+	}
+	slice.value = p
+	*slice.value = value
+	return slice
+}
+
+// Set parses the value into a duration and appends it to the list of values
+func (i *DurationSlice) Set(value string) error {
+	if i.value == nil {
+		i.value = &[]time.Duration{}
+	}
+	if !i.hasBeenSet {
+		*i.value = []time.Duration{}
+		i.hasBeenSet = true
+	}
+
+	if strings.HasPrefix(value, slPfx) {
+		// Deserializing assumes overwrite
+		_ = json.Unmarshal([]byte(strings.Replace(value, slPfx, "", 1)), &(*i.value))
+		i.hasBeenSet = true
+		return nil
+	}
+
+	tmp, err := durationSliceConv(i.splitList(value))
+	if err != nil {
+		return err
+	}
+	*i.value = append(*i.value, tmp...)
+
+	return nil
+}
+
+func durationSliceConv(ss []string) ([]time.Duration, error) {
+	out := make([]time.Duration, len(ss))
+	for i, d := range ss {
+		var err error
+		out[i], err = time.ParseDuration(strings.TrimSpace(d))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// String returns a readable representation of this value (for usage defaults)
+func (i *DurationSlice) String() string {
+	if i.value == nil {
+		return "[]"
+	}
+	out := make([]string, len(*i.value))
+	for i, d := range *i.value {
+		out[i] = d.String()
+	}
+	return "[" + strings.Join(out, ",") + "]"
+}
+
+// Serialize allows DurationSlice to fulfill Serializer
+func (i *DurationSlice) Serialize() string {
+	jsonBytes, _ := json.Marshal(*i.value)
+	return fmt.Sprintf("%s%s", slPfx, string(jsonBytes))
+}
+
+// Value returns the slice of durations set by this flag
+func (i *DurationSlice) Value() []time.Duration {
+	if i.value == nil {
+		i.value = &[]time.Duration{}
+	}
+	return *i.value
+}
+
+// Get returns the slice of durations set by this flag
+func (i *DurationSlice) Get() interface{} {
+	return *i
+}
+
+// DurationSliceFlag is a flag with type *DurationSlice
+type DurationSliceFlag struct {
+	Name         string
+	Aliases      []string
+	Usage        string
+	EnvVars      []string
+	FilePath     string
+	Required     bool
+	Hidden       bool
+	Sensitive    bool
+	Separator    string
+	DisableSplit bool
+	EnvSeparator string
+	Value        *DurationSlice
+	DefaultText  string
+	HasBeenSet   bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *DurationSliceFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *DurationSliceFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *DurationSliceFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *DurationSliceFlag) IsRequired() bool {
+	return f.Required
+}
+
+// IsSensitive returns whether or not the flag value must be kept out of
+// help, docs and errors
+func (f *DurationSliceFlag) IsSensitive() bool {
+	return f.Sensitive
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *DurationSliceFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *DurationSliceFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *DurationSliceFlag) GetValue() string {
+	if f.Value != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *DurationSliceFlag) Apply(set *flag.FlagSet) error {
+	sep, envSep := sliceSeparators(f.Separator, f.DisableSplit, f.EnvSeparator)
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		f.Value = &DurationSlice{}
+		f.Value.sliceSeparator = envSep
+
+		if err := f.Value.Set(val); err != nil {
+			return fmt.Errorf("could not parse %q as duration slice value for flag %s: %s", errorValue(f, val), f.Name, redactError(f, err, append([]string{val}, envSep.split(val)...)...))
+		}
+
+		f.HasBeenSet = true
+	}
+
+	if f.Value == nil {
+		f.Value = &DurationSlice{}
+	}
+	f.Value.sliceSeparator = sep
+	for _, name := range f.Names() {
+		set.Var(f.Value, name, f.Usage)
+	}
+
+	return nil
+}
+
+func (a *App) durationSliceVar(p *[]time.Duration, name, alias string, value []time.Duration, usage, env string) {
+	if a.Flags == nil {
+		a.Flags = make([]Flag, 0)
+	}
+	flag := &DurationSliceFlag{
+		Name:  name,
+		Usage: usage,
+		Value: newDurationSlice(value, p),
+	}
+	if alias != "" {
+		flag.Aliases = []string{alias}
+	}
+	if env != "" {
+		flag.EnvVars = []string{env}
+	}
+	a.Flags = append(a.Flags, flag)
+}
+
+// DurationSliceVar defines a []time.Duration flag with specified name, default value, usage string and env string.
+// The argument p points to a []time.Duration variable in which to store the value of the flag.
+func (a *App) DurationSliceVar(p *[]time.Duration, name string, value []time.Duration, usage, env string) {
+	a.durationSliceVar(p, name, "", value, usage, env)
+}
+
+// DurationSliceVarP is like DurationSliceVar, but accepts a shorthand letter that can be used after a single dash.
+func (a *App) DurationSliceVarP(p *[]time.Duration, name, alias string, value []time.Duration, usage, env string) {
+	a.durationSliceVar(p, name, alias, value, usage, env)
+}
+
+// DurationSliceVar defines a []time.Duration flag with specified name, default value, usage string and env string.
+// The argument p points to a []time.Duration variable in which to store the value of the flag.
+func DurationSliceVar(p *[]time.Duration, name string, value []time.Duration, usage, env string) {
+	CommandLine.DurationSliceVar(p, name, value, usage, env)
+}
+
+// DurationSliceVarP is like DurationSliceVar, but accepts a shorthand letter that can be used after a single dash.
+func DurationSliceVarP(p *[]time.Duration, name, alias string, value []time.Duration, usage, env string) {
+	CommandLine.DurationSliceVarP(p, name, alias, value, usage, env)
+}
+
+// DurationSlice defines a []time.Duration flag with specified name, default value, usage string and env string.
+// The return value is the address of a []time.Duration variable that stores the value of the flag.
+func (a *App) DurationSlice(name string, value []time.Duration, usage, env string) *[]time.Duration {
+	p := new([]time.Duration)
+	a.DurationSliceVar(p, name, value, usage, env)
+	return p
+}
+
+// DurationSliceP is like DurationSlice, but accepts a shorthand letter that can be used after a single dash.
+func (a *App) DurationSliceP(name, alias string, value []time.Duration, usage, env string) *[]time.Duration {
+	p := new([]time.Duration)
+	a.DurationSliceVarP(p, name, alias, value, usage, env)
+	return p
+}
+
+// DurationSlice looks up the value of a local DurationSliceFlag, returns
+// nil if not found
+func (c *Context) DurationSlice(name string) []time.Duration {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return lookupDurationSlice(name, fs)
+	}
+	return nil
+}
+
+func lookupDurationSlice(name string, set *flag.FlagSet) []time.Duration {
+	f := set.Lookup(name)
+	if f != nil {
+		if s, ok := f.Value.(*DurationSlice); ok {
+			return s.Value()
+		}
+	}
+	return nil
+}
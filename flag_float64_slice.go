@@ -26,6 +26,7 @@ import (
 type Float64Slice struct {
 	val        *[]float64
 	hasBeenSet bool
+	sliceSeparator
 }
 
 // NewFloat64Slice makes a *Float64Slice with default values
@@ -57,7 +58,7 @@ func (f *Float64Slice) Set(value string) error {
 		return nil
 	}
 
-	tmp, err := float64SliceConv(value)
+	tmp, err := float64SliceConv(f.splitList(value))
 	if err != nil {
 		return err
 	}
@@ -66,13 +67,7 @@ func (f *Float64Slice) Set(value string) error {
 	return nil
 }
 
-func float64SliceConv(val string) ([]float64, error) {
-	val = strings.Trim(val, "[]")
-	// Empty string would cause a slice with one (empty) entry
-	if len(val) == 0 {
-		return []float64{}, nil
-	}
-	ss := strings.Split(val, ",")
+func float64SliceConv(ss []string) ([]float64, error) {
 	out := make([]float64, len(ss))
 	for i, d := range ss {
 		var err error
@@ -118,16 +113,20 @@ func (f *Float64Slice) Get() interface{} {
 
 // Float64SliceFlag is a flag with type *Float64Slice
 type Float64SliceFlag struct {
-	Name        string
-	Aliases     []string
-	Usage       string
-	EnvVars     []string
-	FilePath    string
-	Required    bool
-	Hidden      bool
-	Value       *Float64Slice
-	DefaultText string
-	HasBeenSet  bool
+	Name         string
+	Aliases      []string
+	Usage        string
+	EnvVars      []string
+	FilePath     string
+	Required     bool
+	Hidden       bool
+	Sensitive    bool
+	Separator    string
+	DisableSplit bool
+	EnvSeparator string
+	Value        *Float64Slice
+	DefaultText  string
+	HasBeenSet   bool
 }
 
 // IsSet returns whether or not the flag has been set through env or file
@@ -151,6 +150,12 @@ func (f *Float64SliceFlag) IsRequired() bool {
 	return f.Required
 }
 
+// IsSensitive returns whether or not the flag value must be kept out of
+// help, docs and errors
+func (f *Float64SliceFlag) IsSensitive() bool {
+	return f.Sensitive
+}
+
 // TakesValue returns true if the flag takes a value, otherwise false
 func (f *Float64SliceFlag) TakesValue() bool {
 	return true
@@ -172,24 +177,25 @@ func (f *Float64SliceFlag) GetValue() string {
 
 // Apply populates the flag given the flag set and environment
 func (f *Float64SliceFlag) Apply(set *flag.FlagSet) error {
+	sep, envSep := sliceSeparators(f.Separator, f.DisableSplit, f.EnvSeparator)
 	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
 		if val != "" {
 			f.Value = &Float64Slice{}
+			f.Value.sliceSeparator = envSep
 
-			for _, s := range strings.Split(val, ",") {
-				if err := f.Value.Set(strings.TrimSpace(s)); err != nil {
-					return fmt.Errorf("could not parse %q as float64 slice value for flag %s: %s", f.Value, f.Name, err)
-				}
+			if err := f.Value.Set(val); err != nil {
+				return fmt.Errorf("could not parse %q as float64 slice value for flag %s: %s", errorValue(f, val), f.Name, redactError(f, err, append([]string{val}, envSep.split(val)...)...))
 			}
 
 			f.HasBeenSet = true
 		}
 	}
 
+	if f.Value == nil {
+		f.Value = &Float64Slice{}
+	}
+	f.Value.sliceSeparator = sep
 	for _, name := range f.Names() {
-		if f.Value == nil {
-			f.Value = &Float64Slice{}
-		}
 		set.Var(f.Value, name, f.Usage)
 	}
 
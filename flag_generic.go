@@ -34,6 +34,7 @@ type GenericFlag struct {
 	FilePath    string
 	Required    bool
 	Hidden      bool
+	Sensitive   bool
 	TakesFile   bool
 	Value       Generic
 	DefaultText string
@@ -61,6 +62,12 @@ func (f *GenericFlag) IsRequired() bool {
 	return f.Required
 }
 
+// IsSensitive returns whether or not the flag value must be kept out of
+// help, docs and errors
+func (f *GenericFlag) IsSensitive() bool {
+	return f.Sensitive
+}
+
 // TakesValue returns true of the flag takes a value, otherwise false
 func (f *GenericFlag) TakesValue() bool {
 	return true
@@ -86,7 +93,7 @@ func (f GenericFlag) Apply(set *flag.FlagSet) error {
 	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
 		if val != "" {
 			if err := f.Value.Set(val); err != nil {
-				return fmt.Errorf("could not parse %q as value for flag %s: %s", val, f.Name, err)
+				return fmt.Errorf("could not parse %q as value for flag %s: %s", errorValue(&f, val), f.Name, redactError(&f, err, val))
 			}
 
 			f.HasBeenSet = true
@@ -0,0 +1,231 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// GenericMap collects key=value pairs like StringMap, parsing each value
+// with a new Generic
+type GenericMap struct {
+	value               map[string]Generic
+	newValue            func() Generic
+	hasBeenSet          bool
+	rejectDuplicateKeys bool
+}
+
+// NewGenericMap creates a *GenericMap parsing the values with the Generic
+// returned by newValue, with default values
+func NewGenericMap(newValue func() Generic, defaults map[string]Generic) *GenericMap {
+	m := &GenericMap{value: make(map[string]Generic, len(defaults)), newValue: newValue}
+	for k, v := range defaults {
+		m.value[k] = v
+	}
+	return m
+}
+
+// Set adds the key=value pairs of value to the map. The first pair set
+// replaces the default values.
+func (m *GenericMap) Set(value string) error {
+	if !m.hasBeenSet || m.value == nil {
+		m.value = map[string]Generic{}
+		m.hasBeenSet = true
+	}
+
+	if strings.HasPrefix(value, slPfx) {
+		// Deserializing assumes overwrite
+		values := map[string]string{}
+		_ = json.Unmarshal([]byte(strings.Replace(value, slPfx, "", 1)), &values)
+		m.value = map[string]Generic{}
+		for k, v := range values {
+			if err := m.setValue(k, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	pairs, err := keyValueConv(value)
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range pairs {
+		if _, ok := m.value[pair[0]]; ok && m.rejectDuplicateKeys {
+			return fmt.Errorf("duplicate key %q", pair[0])
+		}
+		if err := m.setValue(pair[0], pair[1]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *GenericMap) setValue(key, value string) error {
+	if m.newValue == nil {
+		return fmt.Errorf("no value type for key %q, create the map with NewGenericMap", key)
+	}
+
+	v := m.newValue()
+	if err := v.Set(value); err != nil {
+		return fmt.Errorf("invalid value for key %q: %v", key, err)
+	}
+	m.value[key] = v
+	return nil
+}
+
+// String returns a readable representation of this value (for usage defaults)
+func (m *GenericMap) String() string {
+	return strings.Join(stringMapPairs(m.stringMap()), ",")
+}
+
+// Serialize allows GenericMap to fulfill Serializer
+func (m *GenericMap) Serialize() string {
+	jsonBytes, _ := json.Marshal(m.stringMap())
+	return fmt.Sprintf("%s%s", slPfx, string(jsonBytes))
+}
+
+// Value returns the map of values set by this flag
+func (m *GenericMap) Value() map[string]Generic {
+	if m.value == nil {
+		m.value = map[string]Generic{}
+	}
+	return m.value
+}
+
+// Get returns the map of values set by this flag
+func (m *GenericMap) Get() interface{} {
+	return m.Value()
+}
+
+func (m *GenericMap) stringMap() map[string]string {
+	values := make(map[string]string, len(m.value))
+	for k, v := range m.value {
+		values[k] = v.String()
+	}
+	return values
+}
+
+// GenericMapFlag is a flag with type *GenericMap
+type GenericMapFlag struct {
+	Name                string
+	Aliases             []string
+	Usage               string
+	EnvVars             []string
+	FilePath            string
+	Required            bool
+	Hidden              bool
+	Sensitive           bool
+	RejectDuplicateKeys bool
+	Value               *GenericMap
+	DefaultText         string
+	HasBeenSet          bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *GenericMapFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *GenericMapFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *GenericMapFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *GenericMapFlag) IsRequired() bool {
+	return f.Required
+}
+
+// IsSensitive returns whether or not the flag value must be kept out of
+// help, docs and errors
+func (f *GenericMapFlag) IsSensitive() bool {
+	return f.Sensitive
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *GenericMapFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *GenericMapFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *GenericMapFlag) GetValue() string {
+	if f.Value != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+// Apply populates the flag given the flag set and environment. Values given
+// on the command line replace the ones from the environment.
+func (f *GenericMapFlag) Apply(set *flag.FlagSet) error {
+	if f.Value == nil {
+		return fmt.Errorf("flag %s needs a Value created with NewGenericMap", f.Name)
+	}
+	f.Value.rejectDuplicateKeys = f.RejectDuplicateKeys
+
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		f.Value.hasBeenSet = false
+		if err := f.Value.Set(val); err != nil {
+			return fmt.Errorf("could not parse %q as key=value pairs for flag %s: %s", errorValue(f, val), f.Name, redactError(f, err, keyValueStrings(val)...))
+		}
+
+		// the command line replaces the values of the environment
+		f.Value.hasBeenSet = false
+		f.HasBeenSet = true
+	}
+
+	for _, name := range f.Names() {
+		set.Var(f.Value, name, f.Usage)
+	}
+
+	return nil
+}
+
+// GenericMap looks up the value of a local GenericMapFlag, returns
+// nil if not found
+func (c *Context) GenericMap(name string) map[string]Generic {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return lookupGenericMap(name, fs)
+	}
+	return nil
+}
+
+func lookupGenericMap(name string, set *flag.FlagSet) map[string]Generic {
+	f := set.Lookup(name)
+	if f != nil {
+		if m, ok := f.Value.(*GenericMap); ok {
+			return m.Value()
+		}
+	}
+	return nil
+}
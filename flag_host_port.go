@@ -0,0 +1,179 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// parseHostPort validates a host:port pair and returns it in the form of
+// net.JoinHostPort. A host given without a port gets defaultPort, unless it
+// is zero. IPv6 hosts are written in brackets, e.g. [::1]:8080, but may be
+// given bare when the default port applies.
+func parseHostPort(s string, defaultPort int) (string, error) {
+	s = strings.TrimSpace(s)
+	host, port, err := net.SplitHostPort(s)
+	if err != nil {
+		host = strings.TrimSuffix(strings.TrimPrefix(s, "["), "]")
+		if defaultPort == 0 || host == "" || (strings.Contains(host, ":") && net.ParseIP(host) == nil) {
+			return "", fmt.Errorf("invalid host:port %q", s)
+		}
+		port = strconv.Itoa(defaultPort)
+	}
+
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return "", fmt.Errorf("invalid port %q in %q", port, s)
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// hostPortValue is a flag.Value parsing host:port pairs
+type hostPortValue struct {
+	value       *string
+	defaultPort int
+}
+
+func newHostPortValue(val string, p *string, defaultPort int) *hostPortValue {
+	*p = val
+	return &hostPortValue{value: p, defaultPort: defaultPort}
+}
+
+func (h *hostPortValue) Set(s string) error {
+	hostPort, err := parseHostPort(s, h.defaultPort)
+	if err != nil {
+		return err
+	}
+	*h.value = hostPort
+	return nil
+}
+
+func (h *hostPortValue) Get() interface{} { return *h.value }
+
+func (h *hostPortValue) String() string {
+	if h.value == nil {
+		return ""
+	}
+	return *h.value
+}
+
+// HostPortFlag is a flag with type string holding a host:port pair. When
+// DefaultPort is not zero, the port may be omitted.
+type HostPortFlag struct {
+	Name        string
+	Aliases     []string
+	Usage       string
+	EnvVars     []string
+	FilePath    string
+	Required    bool
+	Hidden      bool
+	Value       string
+	DefaultPort int
+	DefaultText string
+	Destination *string
+	HasBeenSet  bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *HostPortFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *HostPortFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *HostPortFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *HostPortFlag) IsRequired() bool {
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *HostPortFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *HostPortFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *HostPortFlag) GetValue() string {
+	return f.Value
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *HostPortFlag) Apply(set *flag.FlagSet) error {
+	if f.Value != "" {
+		hostPort, err := parseHostPort(f.Value, f.DefaultPort)
+		if err != nil {
+			return fmt.Errorf("invalid default value for flag %s: %s", f.Name, err)
+		}
+		f.Value = hostPort
+	}
+
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		if val != "" {
+			hostPort, err := parseHostPort(val, f.DefaultPort)
+			if err != nil {
+				return fmt.Errorf("could not parse %q as host:port value for flag %s: %s", val, f.Name, err)
+			}
+
+			f.Value = hostPort
+			f.HasBeenSet = true
+		}
+	}
+
+	for _, name := range f.Names() {
+		if f.Destination != nil {
+			set.Var(newHostPortValue(f.Value, f.Destination, f.DefaultPort), name, f.Usage)
+			continue
+		}
+		set.Var(newHostPortValue(f.Value, new(string), f.DefaultPort), name, f.Usage)
+	}
+
+	return nil
+}
+
+// HostPort looks up the value of a local HostPortFlag, returns
+// "" if not found
+func (c *Context) HostPort(name string) string {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return lookupHostPort(name, fs)
+	}
+	return ""
+}
+
+func lookupHostPort(name string, set *flag.FlagSet) string {
+	f := set.Lookup(name)
+	if f != nil {
+		if v, ok := f.Value.(*hostPortValue); ok {
+			return *v.value
+		}
+	}
+	return ""
+}
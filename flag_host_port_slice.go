@@ -0,0 +1,195 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// HostPortSlice wraps a []string to satisfy flag.Value
+type HostPortSlice struct {
+	value       []string
+	hasBeenSet  bool
+	defaultPort int
+	sliceSeparator
+}
+
+// NewHostPortSlice creates a *HostPortSlice with default values
+func NewHostPortSlice(defaults ...string) *HostPortSlice {
+	return &HostPortSlice{value: append([]string{}, defaults...)}
+}
+
+// Set parses the comma separated host:port pairs of value and appends them to
+// the list of values
+func (s *HostPortSlice) Set(value string) error {
+	if !s.hasBeenSet {
+		s.value = []string{}
+		s.hasBeenSet = true
+	}
+
+	values, overwrite := s.splitValue(value)
+	if overwrite {
+		s.value = []string{}
+	}
+	for _, v := range values {
+		hostPort, err := parseHostPort(v, s.defaultPort)
+		if err != nil {
+			return err
+		}
+		s.value = append(s.value, hostPort)
+	}
+
+	return nil
+}
+
+// String returns a readable representation of this value (for usage defaults)
+func (s *HostPortSlice) String() string {
+	return "[" + strings.Join(s.strings(), ",") + "]"
+}
+
+// Serialize allows HostPortSlice to fulfill Serializer
+func (s *HostPortSlice) Serialize() string {
+	return serializeSlice(s.strings())
+}
+
+// Value returns the slice of host:port pairs set by this flag
+func (s *HostPortSlice) Value() []string {
+	return s.value
+}
+
+// Get returns the slice of host:port pairs set by this flag
+func (s *HostPortSlice) Get() interface{} {
+	return s.value
+}
+
+func (s *HostPortSlice) strings() []string {
+	if s == nil {
+		return nil
+	}
+	return s.value
+}
+
+// HostPortSliceFlag is a flag with type *HostPortSlice. When DefaultPort is
+// not zero, the ports may be omitted.
+type HostPortSliceFlag struct {
+	Name         string
+	Aliases      []string
+	Usage        string
+	EnvVars      []string
+	FilePath     string
+	Required     bool
+	Hidden       bool
+	Sensitive    bool
+	Separator    string
+	DisableSplit bool
+	EnvSeparator string
+	Value        *HostPortSlice
+	DefaultPort  int
+	DefaultText  string
+	HasBeenSet   bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *HostPortSliceFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *HostPortSliceFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *HostPortSliceFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *HostPortSliceFlag) IsRequired() bool {
+	return f.Required
+}
+
+// IsSensitive returns whether or not the flag value must be kept out of
+// help, docs and errors
+func (f *HostPortSliceFlag) IsSensitive() bool {
+	return f.Sensitive
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *HostPortSliceFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *HostPortSliceFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *HostPortSliceFlag) GetValue() string {
+	if f.Value != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *HostPortSliceFlag) Apply(set *flag.FlagSet) error {
+	sep, envSep := sliceSeparators(f.Separator, f.DisableSplit, f.EnvSeparator)
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		f.Value = &HostPortSlice{defaultPort: f.DefaultPort}
+		f.Value.sliceSeparator = envSep
+
+		if err := f.Value.Set(val); err != nil {
+			return fmt.Errorf("could not parse %q as host:port value for flag %s: %s", errorValue(f, val), f.Name, redactError(f, err, append([]string{val}, envSep.split(val)...)...))
+		}
+
+		f.HasBeenSet = true
+	}
+
+	if f.Value == nil {
+		f.Value = &HostPortSlice{}
+	}
+	f.Value.sliceSeparator = sep
+	f.Value.defaultPort = f.DefaultPort
+	for _, name := range f.Names() {
+		set.Var(f.Value, name, f.Usage)
+	}
+
+	return nil
+}
+
+// HostPortSlice looks up the value of a local HostPortSliceFlag, returns
+// nil if not found
+func (c *Context) HostPortSlice(name string) []string {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return lookupHostPortSlice(name, fs)
+	}
+	return nil
+}
+
+func lookupHostPortSlice(name string, set *flag.FlagSet) []string {
+	f := set.Lookup(name)
+	if f != nil {
+		if s, ok := f.Value.(*HostPortSlice); ok {
+			return s.Value()
+		}
+	}
+	return nil
+}
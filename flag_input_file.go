@@ -0,0 +1,202 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+// stdioPath is the file path standing for stdin or stdout
+const stdioPath = "-"
+
+// checkInputPath reports an error when path is neither stdioPath nor an
+// existing file, so that a wrong path fails while parsing the flags
+func checkInputPath(path string) error {
+	if path == "" || path == stdioPath {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s is a directory", path)
+	}
+	return nil
+}
+
+// inputFile is a flag.Value holding the path of a file to read. The file is
+// opened on first use and closed once the action is done.
+type inputFile struct {
+	path string
+	file io.ReadCloser
+}
+
+func (i *inputFile) Set(path string) error {
+	if err := checkInputPath(path); err != nil {
+		return err
+	}
+	i.path = path
+	return nil
+}
+
+func (i *inputFile) Get() interface{} { return i.path }
+
+func (i *inputFile) String() string {
+	if i == nil {
+		return ""
+	}
+	return i.path
+}
+
+// open opens the file unless it is already open, returns nil when no path
+// has been given
+func (i *inputFile) open() (io.ReadCloser, error) {
+	if i.path == "" {
+		return nil, nil
+	}
+	if i.file == nil {
+		if i.path == stdioPath {
+			i.file = ioutil.NopCloser(os.Stdin)
+		} else {
+			f, err := os.Open(i.path)
+			if err != nil {
+				return nil, err
+			}
+			i.file = f
+		}
+	}
+	return i, nil
+}
+
+func (i *inputFile) Read(p []byte) (int, error) {
+	if i.file == nil {
+		return 0, os.ErrClosed
+	}
+	return i.file.Read(p)
+}
+
+func (i *inputFile) Close() error {
+	return i.closeFile(true)
+}
+
+// closeFile closes the file if it is open
+func (i *inputFile) closeFile(bool) error {
+	if i.file == nil {
+		return nil
+	}
+	err := i.file.Close()
+	i.file = nil
+	return err
+}
+
+// InputFileFlag is a flag holding the path of a file to read, "-" standing
+// for stdin. Context.InputFile opens the file, which is closed automatically
+// after the action and the After hook ran.
+type InputFileFlag struct {
+	Name        string
+	Aliases     []string
+	Usage       string
+	EnvVars     []string
+	FilePath    string
+	Required    bool
+	Hidden      bool
+	Value       string
+	DefaultText string
+	HasBeenSet  bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *InputFileFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *InputFileFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *InputFileFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *InputFileFlag) IsRequired() bool {
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *InputFileFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *InputFileFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *InputFileFlag) GetValue() string {
+	return f.Value
+}
+
+// isFileFlag marks the value of the flag as a file path
+func (f *InputFileFlag) isFileFlag() {}
+
+// Apply populates the flag given the flag set and environment
+func (f *InputFileFlag) Apply(set *flag.FlagSet) error {
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		if err := checkInputPath(val); err != nil {
+			return fmt.Errorf("could not use %q as input file for flag %s: %s", val, f.Name, err)
+		}
+
+		f.Value = val
+		f.HasBeenSet = true
+	}
+
+	value := &inputFile{path: f.Value}
+	for _, name := range f.Names() {
+		set.Var(value, name, f.Usage)
+	}
+
+	return nil
+}
+
+// InputFile opens the file of a local InputFileFlag, returns nil if not
+// found or if no file has been given. The file is closed once the action is
+// done, closing it earlier is allowed.
+func (c *Context) InputFile(name string) (io.ReadCloser, error) {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return lookupInputFile(name, fs)
+	}
+	return nil, nil
+}
+
+func lookupInputFile(name string, set *flag.FlagSet) (io.ReadCloser, error) {
+	f := set.Lookup(name)
+	if f != nil {
+		if v, ok := f.Value.(*inputFile); ok {
+			return v.open()
+		}
+	}
+	return nil, nil
+}
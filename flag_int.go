@@ -17,10 +17,131 @@ package cli
 import (
 	"flag"
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
 )
 
-// IntFlag is a flag with type int
+// countSuffixes maps the SI suffixes accepted by integer flags to the power
+// of 1000 they multiply the value by
+var countSuffixes = map[byte]uint64{
+	'k': 1e3,
+	'K': 1e3,
+	'M': 1e6,
+	'G': 1e9,
+	'T': 1e12,
+	'P': 1e15,
+	'E': 1e18,
+}
+
+// splitCountSuffix splits the SI suffix off s and returns its multiplier, 1
+// when s has no suffix. Hexadecimal numbers have no suffix, as E is a digit.
+func splitCountSuffix(s string) (string, uint64) {
+	if len(s) < 2 {
+		return s, 1
+	}
+	multiplier, ok := countSuffixes[s[len(s)-1]]
+	digits := strings.TrimLeft(s, "+-")
+	if !ok || strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0X") {
+		return s, 1
+	}
+	return s[:len(s)-1], multiplier
+}
+
+// parseInt parses s as a signed integer of bitSize bits. Like Go literals,
+// s may be hexadecimal (0x1F), octal (0o755 or 0755) or binary (0b1010),
+// with underscores between digits (1_000_000). With siSuffixes, a k, M, G,
+// T, P or E suffix multiplies the value by a power of 1000.
+func parseInt(s string, bitSize int, siSuffixes bool) (int64, error) {
+	s = strings.TrimSpace(s)
+	number, multiplier := s, uint64(1)
+	if siSuffixes {
+		number, multiplier = splitCountSuffix(s)
+	}
+
+	n, err := strconv.ParseInt(number, 0, 64)
+	if err != nil {
+		if isRangeError(err) {
+			return 0, fmt.Errorf("%q is out of range for a %d-bit integer", s, bitSize)
+		}
+		return 0, fmt.Errorf("invalid integer %q", s)
+	}
+
+	m := int64(multiplier)
+	if n > math.MaxInt64/m || n < math.MinInt64/m {
+		return 0, fmt.Errorf("%q is out of range for a %d-bit integer", s, bitSize)
+	}
+	n *= m
+	if bitSize < 64 && (n < -1<<uint(bitSize-1) || n > 1<<uint(bitSize-1)-1) {
+		return 0, fmt.Errorf("%q is out of range for a %d-bit integer", s, bitSize)
+	}
+	return n, nil
+}
+
+// parseUint parses s as an unsigned integer of bitSize bits, written like
+// the values of parseInt
+func parseUint(s string, bitSize int, siSuffixes bool) (uint64, error) {
+	s = strings.TrimSpace(s)
+	number, multiplier := s, uint64(1)
+	if siSuffixes {
+		number, multiplier = splitCountSuffix(s)
+	}
+
+	n, err := strconv.ParseUint(number, 0, 64)
+	if err != nil {
+		if _, serr := strconv.ParseInt(number, 0, 64); isRangeError(err) || serr == nil || isRangeError(serr) {
+			return 0, fmt.Errorf("%q is out of range for a %d-bit unsigned integer", s, bitSize)
+		}
+		return 0, fmt.Errorf("invalid integer %q", s)
+	}
+
+	if n > math.MaxUint64/multiplier {
+		return 0, fmt.Errorf("%q is out of range for a %d-bit unsigned integer", s, bitSize)
+	}
+	n *= multiplier
+	if bitSize < 64 && n > 1<<uint(bitSize)-1 {
+		return 0, fmt.Errorf("%q is out of range for a %d-bit unsigned integer", s, bitSize)
+	}
+	return n, nil
+}
+
+func isRangeError(err error) bool {
+	numErr, ok := err.(*strconv.NumError)
+	return ok && numErr.Err == strconv.ErrRange
+}
+
+// intValue is a flag.Value parsing int values, with SI suffixes when enabled
+type intValue struct {
+	value      *int
+	siSuffixes bool
+}
+
+func newIntValue(val int, p *int, siSuffixes bool) *intValue {
+	*p = val
+	return &intValue{value: p, siSuffixes: siSuffixes}
+}
+
+func (v *intValue) Set(s string) error {
+	n, err := parseInt(s, strconv.IntSize, v.siSuffixes)
+	if err != nil {
+		return err
+	}
+	*v.value = int(n)
+	return nil
+}
+
+func (v *intValue) Get() interface{} { return *v.value }
+
+func (v *intValue) String() string {
+	if v.value == nil {
+		return "0"
+	}
+	return strconv.Itoa(*v.value)
+}
+
+// IntFlag is a flag with type int. Values may be hexadecimal, octal or
+// binary and contain underscores, e.g. 0x1F, 0o755, 0b1010 or 1_000_000.
+// With SISuffixes, counts like 10k or 2M are accepted too.
 type IntFlag struct {
 	Name        string
 	Aliases     []string
@@ -29,6 +150,7 @@ type IntFlag struct {
 	FilePath    string
 	Required    bool
 	Hidden      bool
+	SISuffixes  bool
 	Value       int
 	DefaultText string
 	Destination *int
@@ -76,8 +198,7 @@ func (f *IntFlag) GetValue() string {
 func (f *IntFlag) Apply(set *flag.FlagSet) error {
 	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
 		if val != "" {
-			valInt, err := strconv.ParseInt(val, 0, 64)
-
+			valInt, err := parseInt(val, strconv.IntSize, f.SISuffixes)
 			if err != nil {
 				return fmt.Errorf("could not parse %q as int value for flag %s: %s", val, f.Name, err)
 			}
@@ -89,10 +210,10 @@ func (f *IntFlag) Apply(set *flag.FlagSet) error {
 
 	for _, name := range f.Names() {
 		if f.Destination != nil {
-			set.IntVar(f.Destination, name, f.Value, f.Usage)
+			set.Var(newIntValue(f.Value, f.Destination, f.SISuffixes), name, f.Usage)
 			continue
 		}
-		set.Int(name, f.Value, f.Usage)
+		set.Var(newIntValue(f.Value, new(int), f.SISuffixes), name, f.Usage)
 	}
 
 	return nil
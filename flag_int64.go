@@ -20,7 +20,36 @@ import (
 	"strconv"
 )
 
-// Int64Flag is a flag with type int64
+// int64Value is a flag.Value parsing int64 values, with SI suffixes when enabled
+type int64Value struct {
+	value      *int64
+	siSuffixes bool
+}
+
+func newInt64Value(val int64, p *int64, siSuffixes bool) *int64Value {
+	*p = val
+	return &int64Value{value: p, siSuffixes: siSuffixes}
+}
+
+func (v *int64Value) Set(s string) error {
+	n, err := parseInt(s, 64, v.siSuffixes)
+	if err != nil {
+		return err
+	}
+	*v.value = n
+	return nil
+}
+
+func (v *int64Value) Get() interface{} { return *v.value }
+
+func (v *int64Value) String() string {
+	if v.value == nil {
+		return "0"
+	}
+	return strconv.FormatInt(*v.value, 10)
+}
+
+// Int64Flag is a flag with type int64, parsing values like an IntFlag
 type Int64Flag struct {
 	Name        string
 	Aliases     []string
@@ -29,6 +58,7 @@ type Int64Flag struct {
 	FilePath    string
 	Required    bool
 	Hidden      bool
+	SISuffixes  bool
 	Value       int64
 	DefaultText string
 	Destination *int64
@@ -76,8 +106,7 @@ func (f *Int64Flag) GetValue() string {
 func (f *Int64Flag) Apply(set *flag.FlagSet) error {
 	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
 		if val != "" {
-			valInt, err := strconv.ParseInt(val, 0, 64)
-
+			valInt, err := parseInt(val, 64, f.SISuffixes)
 			if err != nil {
 				return fmt.Errorf("could not parse %q as int value for flag %s: %s", val, f.Name, err)
 			}
@@ -89,10 +118,10 @@ func (f *Int64Flag) Apply(set *flag.FlagSet) error {
 
 	for _, name := range f.Names() {
 		if f.Destination != nil {
-			set.Int64Var(f.Destination, name, f.Value, f.Usage)
+			set.Var(newInt64Value(f.Value, f.Destination, f.SISuffixes), name, f.Usage)
 			continue
 		}
-		set.Int64(name, f.Value, f.Usage)
+		set.Var(newInt64Value(f.Value, new(int64), f.SISuffixes), name, f.Usage)
 	}
 	return nil
 }
@@ -26,6 +26,7 @@ import (
 type Int64Slice struct {
 	value      *[]int64
 	hasBeenSet bool
+	sliceSeparator
 }
 
 // NewInt64Slice makes an *Int64Slice with default values
@@ -57,7 +58,7 @@ func (i *Int64Slice) Set(value string) error {
 		return nil
 	}
 
-	tmp, err := int64SliceConv(value)
+	tmp, err := int64SliceConv(i.splitList(value))
 	if err != nil {
 		return err
 	}
@@ -66,13 +67,7 @@ func (i *Int64Slice) Set(value string) error {
 	return nil
 }
 
-func int64SliceConv(val string) ([]int64, error) {
-	val = strings.Trim(val, "[]")
-	// Empty string would cause a slice with one (empty) entry
-	if len(val) == 0 {
-		return []int64{}, nil
-	}
-	ss := strings.Split(val, ",")
+func int64SliceConv(ss []string) ([]int64, error) {
 	out := make([]int64, len(ss))
 	for i, d := range ss {
 		var err error
@@ -118,16 +113,20 @@ func (i *Int64Slice) Get() interface{} {
 
 // Int64SliceFlag is a flag with type *Int64Slice
 type Int64SliceFlag struct {
-	Name        string
-	Aliases     []string
-	Usage       string
-	EnvVars     []string
-	FilePath    string
-	Required    bool
-	Hidden      bool
-	Value       *Int64Slice
-	DefaultText string
-	HasBeenSet  bool
+	Name         string
+	Aliases      []string
+	Usage        string
+	EnvVars      []string
+	FilePath     string
+	Required     bool
+	Hidden       bool
+	Sensitive    bool
+	Separator    string
+	DisableSplit bool
+	EnvSeparator string
+	Value        *Int64Slice
+	DefaultText  string
+	HasBeenSet   bool
 }
 
 // IsSet returns whether or not the flag has been set through env or file
@@ -151,6 +150,12 @@ func (f *Int64SliceFlag) IsRequired() bool {
 	return f.Required
 }
 
+// IsSensitive returns whether or not the flag value must be kept out of
+// help, docs and errors
+func (f *Int64SliceFlag) IsSensitive() bool {
+	return f.Sensitive
+}
+
 // TakesValue returns true of the flag takes a value, otherwise false
 func (f *Int64SliceFlag) TakesValue() bool {
 	return true
@@ -172,22 +177,23 @@ func (f *Int64SliceFlag) GetValue() string {
 
 // Apply populates the flag given the flag set and environment
 func (f *Int64SliceFlag) Apply(set *flag.FlagSet) error {
+	sep, envSep := sliceSeparators(f.Separator, f.DisableSplit, f.EnvSeparator)
 	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
 		f.Value = &Int64Slice{}
+		f.Value.sliceSeparator = envSep
 
-		for _, s := range strings.Split(val, ",") {
-			if err := f.Value.Set(strings.TrimSpace(s)); err != nil {
-				return fmt.Errorf("could not parse %q as int64 slice value for flag %s: %s", val, f.Name, err)
-			}
+		if err := f.Value.Set(val); err != nil {
+			return fmt.Errorf("could not parse %q as int64 slice value for flag %s: %s", errorValue(f, val), f.Name, redactError(f, err, append([]string{val}, envSep.split(val)...)...))
 		}
 
 		f.HasBeenSet = true
 	}
 
+	if f.Value == nil {
+		f.Value = &Int64Slice{}
+	}
+	f.Value.sliceSeparator = sep
 	for _, name := range f.Names() {
-		if f.Value == nil {
-			f.Value = &Int64Slice{}
-		}
 		set.Var(f.Value, name, f.Usage)
 	}
 
@@ -26,6 +26,7 @@ import (
 type IntSlice struct {
 	value      *[]int
 	hasBeenSet bool
+	sliceSeparator
 }
 
 // NewIntSlice makes an *IntSlice with default values
@@ -68,7 +69,7 @@ func (i *IntSlice) Set(value string) error {
 		return nil
 	}
 
-	tmp, err := intSliceConv(value)
+	tmp, err := intSliceConv(i.splitList(value))
 	if err != nil {
 		return err
 	}
@@ -78,13 +79,7 @@ func (i *IntSlice) Set(value string) error {
 	return nil
 }
 
-func intSliceConv(val string) ([]int, error) {
-	val = strings.Trim(val, "[]")
-	// Empty string would cause a slice with one (empty) entry
-	if len(val) == 0 {
-		return []int{}, nil
-	}
-	ss := strings.Split(val, ",")
+func intSliceConv(ss []string) ([]int, error) {
 	out := make([]int, len(ss))
 	for i, d := range ss {
 		var err error
@@ -130,16 +125,20 @@ func (i *IntSlice) Get() interface{} {
 
 // IntSliceFlag is a flag with type *IntSlice
 type IntSliceFlag struct {
-	Name        string
-	Aliases     []string
-	Usage       string
-	EnvVars     []string
-	FilePath    string
-	Required    bool
-	Hidden      bool
-	Value       *IntSlice
-	DefaultText string
-	HasBeenSet  bool
+	Name         string
+	Aliases      []string
+	Usage        string
+	EnvVars      []string
+	FilePath     string
+	Required     bool
+	Hidden       bool
+	Sensitive    bool
+	Separator    string
+	DisableSplit bool
+	EnvSeparator string
+	Value        *IntSlice
+	DefaultText  string
+	HasBeenSet   bool
 }
 
 // IsSet returns whether or not the flag has been set through env or file
@@ -163,6 +162,12 @@ func (f *IntSliceFlag) IsRequired() bool {
 	return f.Required
 }
 
+// IsSensitive returns whether or not the flag value must be kept out of
+// help, docs and errors
+func (f *IntSliceFlag) IsSensitive() bool {
+	return f.Sensitive
+}
+
 // TakesValue returns true of the flag takes a value, otherwise false
 func (f *IntSliceFlag) TakesValue() bool {
 	return true
@@ -184,22 +189,23 @@ func (f *IntSliceFlag) GetValue() string {
 
 // Apply populates the flag given the flag set and environment
 func (f *IntSliceFlag) Apply(set *flag.FlagSet) error {
+	sep, envSep := sliceSeparators(f.Separator, f.DisableSplit, f.EnvSeparator)
 	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
 		f.Value = &IntSlice{}
+		f.Value.sliceSeparator = envSep
 
-		for _, s := range strings.Split(val, ",") {
-			if err := f.Value.Set(strings.TrimSpace(s)); err != nil {
-				return fmt.Errorf("could not parse %q as int slice value for flag %s: %s", val, f.Name, err)
-			}
+		if err := f.Value.Set(val); err != nil {
+			return fmt.Errorf("could not parse %q as int slice value for flag %s: %s", errorValue(f, val), f.Name, redactError(f, err, append([]string{val}, envSep.split(val)...)...))
 		}
 
 		f.HasBeenSet = true
 	}
 
+	if f.Value == nil {
+		f.Value = &IntSlice{}
+	}
+	f.Value.sliceSeparator = sep
 	for _, name := range f.Names() {
-		if f.Value == nil {
-			f.Value = &IntSlice{}
-		}
 		set.Var(f.Value, name, f.Usage)
 	}
 
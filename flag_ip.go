@@ -0,0 +1,155 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"strings"
+)
+
+func parseIP(s string) (net.IP, error) {
+	ip := net.ParseIP(strings.TrimSpace(s))
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address %q", s)
+	}
+	return ip, nil
+}
+
+// ipValue is a flag.Value parsing IP addresses
+type ipValue net.IP
+
+func newIPValue(val net.IP, p *net.IP) *ipValue {
+	*p = val
+	return (*ipValue)(p)
+}
+
+func (i *ipValue) Set(s string) error {
+	ip, err := parseIP(s)
+	if err != nil {
+		return err
+	}
+	*i = ipValue(ip)
+	return nil
+}
+
+func (i *ipValue) Get() interface{} { return net.IP(*i) }
+
+func (i *ipValue) String() string {
+	if len(*i) == 0 {
+		return ""
+	}
+	return net.IP(*i).String()
+}
+
+// IPFlag is a flag with type net.IP
+type IPFlag struct {
+	Name        string
+	Aliases     []string
+	Usage       string
+	EnvVars     []string
+	FilePath    string
+	Required    bool
+	Hidden      bool
+	Value       net.IP
+	DefaultText string
+	Destination *net.IP
+	HasBeenSet  bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *IPFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *IPFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *IPFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *IPFlag) IsRequired() bool {
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *IPFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *IPFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *IPFlag) GetValue() string {
+	if len(f.Value) == 0 {
+		return ""
+	}
+	return f.Value.String()
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *IPFlag) Apply(set *flag.FlagSet) error {
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		if val != "" {
+			ip, err := parseIP(val)
+			if err != nil {
+				return fmt.Errorf("could not parse %q as IP value for flag %s: %s", val, f.Name, err)
+			}
+
+			f.Value = ip
+			f.HasBeenSet = true
+		}
+	}
+
+	for _, name := range f.Names() {
+		if f.Destination != nil {
+			set.Var(newIPValue(f.Value, f.Destination), name, f.Usage)
+			continue
+		}
+		set.Var(newIPValue(f.Value, new(net.IP)), name, f.Usage)
+	}
+
+	return nil
+}
+
+// IP looks up the value of a local IPFlag, returns
+// nil if not found
+func (c *Context) IP(name string) net.IP {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return lookupIP(name, fs)
+	}
+	return nil
+}
+
+func lookupIP(name string, set *flag.FlagSet) net.IP {
+	f := set.Lookup(name)
+	if f != nil {
+		if v, ok := f.Value.(*ipValue); ok && len(*v) > 0 {
+			return net.IP(*v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// parseIPNet parses a CIDR notation like 10.0.0.0/8 or fd00::/8. The host
+// bits of the address are cleared, so 10.1.2.3/8 parses as 10.0.0.0/8.
+func parseIPNet(s string) (*net.IPNet, error) {
+	_, n, err := net.ParseCIDR(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR address %q", s)
+	}
+	return n, nil
+}
+
+// ipNetValue is a flag.Value parsing CIDR notations, copying the network to
+// dest when set
+type ipNetValue struct {
+	value *net.IPNet
+	dest  *net.IPNet
+}
+
+func newIPNetValue(val *net.IPNet, p *net.IPNet) *ipNetValue {
+	v := &ipNetValue{value: val, dest: p}
+	if val != nil && p != nil {
+		*p = *val
+	}
+	return v
+}
+
+func (i *ipNetValue) Set(s string) error {
+	n, err := parseIPNet(s)
+	if err != nil {
+		return err
+	}
+	i.value = n
+	if i.dest != nil {
+		*i.dest = *n
+	}
+	return nil
+}
+
+func (i *ipNetValue) Get() interface{} { return i.value }
+
+func (i *ipNetValue) String() string {
+	if i.value == nil {
+		return ""
+	}
+	return i.value.String()
+}
+
+// IPNetFlag is a flag with type *net.IPNet given in CIDR notation
+type IPNetFlag struct {
+	Name        string
+	Aliases     []string
+	Usage       string
+	EnvVars     []string
+	FilePath    string
+	Required    bool
+	Hidden      bool
+	Value       *net.IPNet
+	DefaultText string
+	Destination *net.IPNet
+	HasBeenSet  bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *IPNetFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *IPNetFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *IPNetFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *IPNetFlag) IsRequired() bool {
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *IPNetFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *IPNetFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *IPNetFlag) GetValue() string {
+	if f.Value == nil {
+		return ""
+	}
+	return f.Value.String()
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *IPNetFlag) Apply(set *flag.FlagSet) error {
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		if val != "" {
+			n, err := parseIPNet(val)
+			if err != nil {
+				return fmt.Errorf("could not parse %q as CIDR value for flag %s: %s", val, f.Name, err)
+			}
+
+			f.Value = n
+			f.HasBeenSet = true
+		}
+	}
+
+	for _, name := range f.Names() {
+		set.Var(newIPNetValue(f.Value, f.Destination), name, f.Usage)
+	}
+
+	return nil
+}
+
+// IPNet looks up the value of a local IPNetFlag, returns
+// nil if not found
+func (c *Context) IPNet(name string) *net.IPNet {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return lookupIPNet(name, fs)
+	}
+	return nil
+}
+
+func lookupIPNet(name string, set *flag.FlagSet) *net.IPNet {
+	f := set.Lookup(name)
+	if f != nil {
+		if v, ok := f.Value.(*ipNetValue); ok {
+			return v.value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// IPNetSlice wraps a []*net.IPNet to satisfy flag.Value
+type IPNetSlice struct {
+	value      []*net.IPNet
+	hasBeenSet bool
+	sliceSeparator
+}
+
+// NewIPNetSlice creates a *IPNetSlice with default values
+func NewIPNetSlice(defaults ...*net.IPNet) *IPNetSlice {
+	return &IPNetSlice{value: append([]*net.IPNet{}, defaults...)}
+}
+
+// Set parses the comma separated CIDR notations of value and appends them to
+// the list of values
+func (s *IPNetSlice) Set(value string) error {
+	if !s.hasBeenSet {
+		s.value = []*net.IPNet{}
+		s.hasBeenSet = true
+	}
+
+	values, overwrite := s.splitValue(value)
+	if overwrite {
+		s.value = []*net.IPNet{}
+	}
+	for _, v := range values {
+		n, err := parseIPNet(v)
+		if err != nil {
+			return err
+		}
+		s.value = append(s.value, n)
+	}
+
+	return nil
+}
+
+// String returns a readable representation of this value (for usage defaults)
+func (s *IPNetSlice) String() string {
+	return "[" + strings.Join(s.strings(), ",") + "]"
+}
+
+// Serialize allows IPNetSlice to fulfill Serializer
+func (s *IPNetSlice) Serialize() string {
+	return serializeSlice(s.strings())
+}
+
+// Value returns the slice of networks set by this flag
+func (s *IPNetSlice) Value() []*net.IPNet {
+	return s.value
+}
+
+// Get returns the slice of networks set by this flag
+func (s *IPNetSlice) Get() interface{} {
+	return s.value
+}
+
+func (s *IPNetSlice) strings() []string {
+	if s == nil {
+		return nil
+	}
+	out := make([]string, len(s.value))
+	for i, n := range s.value {
+		out[i] = n.String()
+	}
+	return out
+}
+
+// IPNetSliceFlag is a flag with type *IPNetSlice
+type IPNetSliceFlag struct {
+	Name         string
+	Aliases      []string
+	Usage        string
+	EnvVars      []string
+	FilePath     string
+	Required     bool
+	Hidden       bool
+	Sensitive    bool
+	Separator    string
+	DisableSplit bool
+	EnvSeparator string
+	Value        *IPNetSlice
+	DefaultText  string
+	HasBeenSet   bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *IPNetSliceFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *IPNetSliceFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *IPNetSliceFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *IPNetSliceFlag) IsRequired() bool {
+	return f.Required
+}
+
+// IsSensitive returns whether or not the flag value must be kept out of
+// help, docs and errors
+func (f *IPNetSliceFlag) IsSensitive() bool {
+	return f.Sensitive
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *IPNetSliceFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *IPNetSliceFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *IPNetSliceFlag) GetValue() string {
+	if f.Value != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *IPNetSliceFlag) Apply(set *flag.FlagSet) error {
+	sep, envSep := sliceSeparators(f.Separator, f.DisableSplit, f.EnvSeparator)
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		f.Value = &IPNetSlice{}
+		f.Value.sliceSeparator = envSep
+
+		if err := f.Value.Set(val); err != nil {
+			return fmt.Errorf("could not parse %q as CIDR value for flag %s: %s", errorValue(f, val), f.Name, redactError(f, err, append([]string{val}, envSep.split(val)...)...))
+		}
+
+		f.HasBeenSet = true
+	}
+
+	if f.Value == nil {
+		f.Value = &IPNetSlice{}
+	}
+	f.Value.sliceSeparator = sep
+	for _, name := range f.Names() {
+		set.Var(f.Value, name, f.Usage)
+	}
+
+	return nil
+}
+
+// IPNetSlice looks up the value of a local IPNetSliceFlag, returns
+// nil if not found
+func (c *Context) IPNetSlice(name string) []*net.IPNet {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return lookupIPNetSlice(name, fs)
+	}
+	return nil
+}
+
+func lookupIPNetSlice(name string, set *flag.FlagSet) []*net.IPNet {
+	f := set.Lookup(name)
+	if f != nil {
+		if s, ok := f.Value.(*IPNetSlice); ok {
+			return s.Value()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// IPSlice wraps a []net.IP to satisfy flag.Value
+type IPSlice struct {
+	value      []net.IP
+	hasBeenSet bool
+	sliceSeparator
+}
+
+// NewIPSlice creates a *IPSlice with default values
+func NewIPSlice(defaults ...net.IP) *IPSlice {
+	return &IPSlice{value: append([]net.IP{}, defaults...)}
+}
+
+// Set parses the comma separated IP addresses of value and appends them to
+// the list of values
+func (s *IPSlice) Set(value string) error {
+	if !s.hasBeenSet {
+		s.value = []net.IP{}
+		s.hasBeenSet = true
+	}
+
+	values, overwrite := s.splitValue(value)
+	if overwrite {
+		s.value = []net.IP{}
+	}
+	for _, v := range values {
+		ip, err := parseIP(v)
+		if err != nil {
+			return err
+		}
+		s.value = append(s.value, ip)
+	}
+
+	return nil
+}
+
+// String returns a readable representation of this value (for usage defaults)
+func (s *IPSlice) String() string {
+	return "[" + strings.Join(s.strings(), ",") + "]"
+}
+
+// Serialize allows IPSlice to fulfill Serializer
+func (s *IPSlice) Serialize() string {
+	return serializeSlice(s.strings())
+}
+
+// Value returns the slice of IP addresses set by this flag
+func (s *IPSlice) Value() []net.IP {
+	return s.value
+}
+
+// Get returns the slice of IP addresses set by this flag
+func (s *IPSlice) Get() interface{} {
+	return s.value
+}
+
+func (s *IPSlice) strings() []string {
+	if s == nil {
+		return nil
+	}
+	out := make([]string, len(s.value))
+	for i, ip := range s.value {
+		out[i] = ip.String()
+	}
+	return out
+}
+
+// IPSliceFlag is a flag with type *IPSlice
+type IPSliceFlag struct {
+	Name         string
+	Aliases      []string
+	Usage        string
+	EnvVars      []string
+	FilePath     string
+	Required     bool
+	Hidden       bool
+	Sensitive    bool
+	Separator    string
+	DisableSplit bool
+	EnvSeparator string
+	Value        *IPSlice
+	DefaultText  string
+	HasBeenSet   bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *IPSliceFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *IPSliceFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *IPSliceFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *IPSliceFlag) IsRequired() bool {
+	return f.Required
+}
+
+// IsSensitive returns whether or not the flag value must be kept out of
+// help, docs and errors
+func (f *IPSliceFlag) IsSensitive() bool {
+	return f.Sensitive
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *IPSliceFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *IPSliceFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *IPSliceFlag) GetValue() string {
+	if f.Value != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *IPSliceFlag) Apply(set *flag.FlagSet) error {
+	sep, envSep := sliceSeparators(f.Separator, f.DisableSplit, f.EnvSeparator)
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		f.Value = &IPSlice{}
+		f.Value.sliceSeparator = envSep
+
+		if err := f.Value.Set(val); err != nil {
+			return fmt.Errorf("could not parse %q as IP value for flag %s: %s", errorValue(f, val), f.Name, redactError(f, err, append([]string{val}, envSep.split(val)...)...))
+		}
+
+		f.HasBeenSet = true
+	}
+
+	if f.Value == nil {
+		f.Value = &IPSlice{}
+	}
+	f.Value.sliceSeparator = sep
+	for _, name := range f.Names() {
+		set.Var(f.Value, name, f.Usage)
+	}
+
+	return nil
+}
+
+// IPSlice looks up the value of a local IPSliceFlag, returns
+// nil if not found
+func (c *Context) IPSlice(name string) []net.IP {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return lookupIPSlice(name, fs)
+	}
+	return nil
+}
+
+func lookupIPSlice(name string, set *flag.FlagSet) []net.IP {
+	f := set.Lookup(name)
+	if f != nil {
+		if s, ok := f.Value.(*IPSlice); ok {
+			return s.Value()
+		}
+	}
+	return nil
+}
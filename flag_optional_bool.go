@@ -0,0 +1,194 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+)
+
+// OptionalBool wraps a *bool to satisfy flag.Value. Unlike a plain bool it
+// tells a flag that was never given apart from one set to false.
+type OptionalBool struct {
+	value   *bool
+	lenient bool
+}
+
+// NewOptionalBool creates an *OptionalBool holding value, or unset if value
+// is nil
+func NewOptionalBool(value *bool) *OptionalBool {
+	b := &OptionalBool{}
+	if value != nil {
+		v := *value
+		b.value = &v
+	}
+	return b
+}
+
+// Set parses value as a bool
+func (b *OptionalBool) Set(value string) error {
+	v, err := parseBool(value, b.lenient)
+	if err != nil {
+		return err
+	}
+	b.value = &v
+	return nil
+}
+
+// String returns a readable representation of this value, which is empty
+// while unset
+func (b *OptionalBool) String() string {
+	if b == nil || b.value == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b.value)
+}
+
+// Value returns the bool held, or nil while unset
+func (b *OptionalBool) Value() *bool {
+	if b.value == nil {
+		return nil
+	}
+	v := *b.value
+	return &v
+}
+
+// Get returns the bool held, or nil while unset
+func (b *OptionalBool) Get() interface{} {
+	return b.Value()
+}
+
+// IsBoolFlag allows the flag to be given without a value
+func (b *OptionalBool) IsBoolFlag() bool {
+	return true
+}
+
+// OptionalBoolFlag is a bool flag that is unset until given on the command
+// line, through its environment variables or file, so that a config
+// file or another default can decide its value otherwise. Lenient accepts
+// the same spellings as for a BoolFlag.
+type OptionalBoolFlag struct {
+	Name        string
+	Aliases     []string
+	Usage       string
+	EnvVars     []string
+	FilePath    string
+	Required    bool
+	Hidden      bool
+	Negatable   bool
+	Lenient     bool
+	Value       *bool
+	DefaultText string
+	HasBeenSet  bool
+
+	appLenient bool
+}
+
+// setAppLenient makes the flag lenient while it is applied for an app with
+// LenientBools
+func (f *OptionalBoolFlag) setAppLenient(lenient bool) {
+	f.appLenient = lenient
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *OptionalBoolFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *OptionalBoolFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *OptionalBoolFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *OptionalBoolFlag) IsRequired() bool {
+	return f.Required
+}
+
+// NegatedNames returns the --no-<name> forms of the long flag names when the
+// flag is negatable
+func (f *OptionalBoolFlag) NegatedNames() []string {
+	if !f.Negatable {
+		return nil
+	}
+	return negatedNames(f.Names())
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *OptionalBoolFlag) TakesValue() bool {
+	return false
+}
+
+// GetUsage returns the usage string for the flag
+func (f *OptionalBoolFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *OptionalBoolFlag) GetValue() string {
+	return ""
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *OptionalBoolFlag) Apply(set *flag.FlagSet) error {
+	value := NewOptionalBool(f.Value)
+	value.lenient = f.Lenient || f.appLenient
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		if val != "" {
+			if err := value.Set(val); err != nil {
+				return fmt.Errorf("could not parse %q as bool value for flag %s: %s", val, f.Name, err)
+			}
+
+			f.HasBeenSet = true
+		}
+	}
+
+	for _, name := range f.Names() {
+		set.Var(value, name, f.Usage)
+	}
+
+	if f.Negatable {
+		applyNegation(set, f.Names(), f.Usage, value.lenient)
+	}
+
+	return nil
+}
+
+// OptionalBool looks up the value of a local OptionalBoolFlag, returns
+// nil if not found or unset
+func (c *Context) OptionalBool(name string) *bool {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return lookupOptionalBool(name, fs)
+	}
+	return nil
+}
+
+func lookupOptionalBool(name string, set *flag.FlagSet) *bool {
+	f := set.Lookup(name)
+	if f != nil {
+		if b, ok := f.Value.(*OptionalBool); ok {
+			return b.Value()
+		}
+	}
+	return nil
+}
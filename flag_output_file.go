@@ -0,0 +1,249 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// defaultOutputPerm is the permission of the files created by an
+// OutputFileFlag without Perm
+const defaultOutputPerm os.FileMode = 0644
+
+// nopWriteCloser is an io.WriteCloser whose Close does nothing, used to keep
+// stdout open
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// outputFile is a flag.Value holding the path of a file to write. The file
+// is opened on first use and closed once the action is done. Atomic files
+// are written to a temporary file renamed over the path when the action
+// succeeded.
+type outputFile struct {
+	path   string
+	append bool
+	atomic bool
+	perm   os.FileMode
+	file   io.WriteCloser
+	tmp    string
+}
+
+func (o *outputFile) Set(path string) error {
+	o.path = path
+	return nil
+}
+
+func (o *outputFile) Get() interface{} { return o.path }
+
+func (o *outputFile) String() string {
+	if o == nil {
+		return ""
+	}
+	return o.path
+}
+
+// open opens the file unless it is already open, returns nil when no path
+// has been given
+func (o *outputFile) open(stdout io.Writer) (io.WriteCloser, error) {
+	if o.path == "" {
+		return nil, nil
+	}
+	if o.file != nil {
+		return o, nil
+	}
+
+	switch {
+	case o.path == stdioPath:
+		if stdout == nil {
+			stdout = os.Stdout
+		}
+		o.file = nopWriteCloser{stdout}
+	case o.atomic:
+		f, err := ioutil.TempFile(filepath.Dir(o.path), "."+filepath.Base(o.path)+".*.tmp")
+		if err != nil {
+			return nil, err
+		}
+		o.file = f
+		o.tmp = f.Name()
+	default:
+		mode := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		if o.append {
+			mode = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+		}
+		f, err := os.OpenFile(o.path, mode, o.perm)
+		if err != nil {
+			return nil, err
+		}
+		o.file = f
+	}
+	return o, nil
+}
+
+func (o *outputFile) Write(p []byte) (int, error) {
+	if o.file == nil {
+		return 0, os.ErrClosed
+	}
+	return o.file.Write(p)
+}
+
+// Close closes the file, replacing the path with an atomic file
+func (o *outputFile) Close() error {
+	return o.closeFile(true)
+}
+
+// closeFile closes the file if it is open. An atomic file replaces the path
+// only when commit is true and is removed otherwise.
+func (o *outputFile) closeFile(commit bool) error {
+	if o.file == nil {
+		return nil
+	}
+	err := o.file.Close()
+	o.file = nil
+	if o.tmp == "" {
+		return err
+	}
+
+	tmp := o.tmp
+	o.tmp = ""
+	if err == nil && commit {
+		perm := o.perm
+		if info, statErr := os.Stat(o.path); statErr == nil {
+			perm = info.Mode().Perm()
+		}
+		if err = os.Chmod(tmp, perm); err == nil {
+			err = os.Rename(tmp, o.path)
+		}
+	}
+	if err != nil || !commit {
+		_ = os.Remove(tmp)
+	}
+	return err
+}
+
+// OutputFileFlag is a flag holding the path of a file to write, "-" standing
+// for stdout. Context.OutputFile creates or truncates the file, or appends
+// to it with Append. With Atomic, the output goes to a temporary file which
+// replaces the file only if the action succeeds. The file is closed
+// automatically after the action and the After hook ran.
+type OutputFileFlag struct {
+	Name        string
+	Aliases     []string
+	Usage       string
+	EnvVars     []string
+	FilePath    string
+	Required    bool
+	Hidden      bool
+	Value       string
+	Append      bool
+	Atomic      bool
+	Perm        os.FileMode
+	DefaultText string
+	HasBeenSet  bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *OutputFileFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *OutputFileFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *OutputFileFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *OutputFileFlag) IsRequired() bool {
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *OutputFileFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *OutputFileFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *OutputFileFlag) GetValue() string {
+	return f.Value
+}
+
+// isFileFlag marks the value of the flag as a file path
+func (f *OutputFileFlag) isFileFlag() {}
+
+// Apply populates the flag given the flag set and environment
+func (f *OutputFileFlag) Apply(set *flag.FlagSet) error {
+	if f.Append && f.Atomic {
+		return fmt.Errorf("flag %s cannot both append to and atomically replace its file", f.Name)
+	}
+
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		f.Value = val
+		f.HasBeenSet = true
+	}
+
+	perm := f.Perm
+	if perm == 0 {
+		perm = defaultOutputPerm
+	}
+	value := &outputFile{path: f.Value, append: f.Append, atomic: f.Atomic, perm: perm}
+	for _, name := range f.Names() {
+		set.Var(value, name, f.Usage)
+	}
+
+	return nil
+}
+
+// OutputFile opens the file of a local OutputFileFlag, returns nil if not
+// found or if no file has been given. "-" writes to the App Writer. The file
+// is closed once the action is done, closing it earlier is allowed.
+func (c *Context) OutputFile(name string) (io.WriteCloser, error) {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		var stdout io.Writer
+		if c.App != nil {
+			stdout = c.App.Writer
+		}
+		return lookupOutputFile(name, fs, stdout)
+	}
+	return nil, nil
+}
+
+func lookupOutputFile(name string, set *flag.FlagSet, stdout io.Writer) (io.WriteCloser, error) {
+	f := set.Lookup(name)
+	if f != nil {
+		if v, ok := f.Value.(*outputFile); ok {
+			return v.open(stdout)
+		}
+	}
+	return nil, nil
+}
@@ -14,8 +14,175 @@
 
 package cli
 
-import "flag"
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
 
+// ExpandPath replaces a leading ~ with the home directory of the user and
+// the $VAR or ${VAR} references with the values of environment variables
+func ExpandPath(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = home + path[1:]
+		}
+	}
+	return os.ExpandEnv(path)
+}
+
+// pathOptions normalizes and checks the paths given to path flags
+type pathOptions struct {
+	expand     bool
+	absolute   bool
+	mustExist  bool
+	mustBeFile bool
+	mustBeDir  bool
+	readable   bool
+	writable   bool
+	sensitive  bool
+}
+
+// name returns the path as shown in errors, redacted for sensitive flags
+func (o pathOptions) name(path string) string {
+	if o.sensitive {
+		return strconv.Quote(redactedValue)
+	}
+	return path
+}
+
+// normalize expands and makes the path absolute as configured
+func (o pathOptions) normalize(path string) (string, error) {
+	if o.expand {
+		path = ExpandPath(path)
+	}
+	if o.absolute {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return "", err
+		}
+		path = abs
+	}
+	return path, nil
+}
+
+// check reports an error when the path does not meet the expectations on
+// its existence, type and permissions
+func (o pathOptions) check(path string) error {
+	if !(o.mustExist || o.mustBeFile || o.mustBeDir || o.readable || o.writable) {
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			if pathErr, ok := err.(*os.PathError); ok && o.sensitive {
+				return fmt.Errorf("%s: %v", o.name(path), pathErr.Err)
+			}
+			return err
+		}
+		if o.mustExist || o.mustBeFile || o.mustBeDir || o.readable {
+			return fmt.Errorf("%s does not exist", o.name(path))
+		}
+		info = nil
+	}
+
+	if o.mustBeFile && info.IsDir() {
+		return fmt.Errorf("%s is a directory, expected a file", o.name(path))
+	}
+	if o.mustBeDir && !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", o.name(path))
+	}
+	if o.readable {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("%s is not readable", o.name(path))
+		}
+		_ = f.Close()
+	}
+	if o.writable && !isWritable(path, info) {
+		return fmt.Errorf("%s is not writable", o.name(path))
+	}
+	return nil
+}
+
+// resolve normalizes and checks the path
+func (o pathOptions) resolve(path string) (string, error) {
+	path, err := o.normalize(path)
+	if err != nil {
+		return "", err
+	}
+	if err := o.check(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// isWritable returns whether the file can be opened for writing, or a file
+// created in the directory. A missing path is writable when its parent
+// directory is.
+func isWritable(path string, info os.FileInfo) bool {
+	if info == nil || info.IsDir() {
+		dir := path
+		if info == nil {
+			dir = filepath.Dir(path)
+		}
+		f, err := ioutil.TempFile(dir, ".write-check-*")
+		if err != nil {
+			return false
+		}
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return true
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return false
+	}
+	_ = f.Close()
+	return true
+}
+
+// pathValue is a flag.Value resolving paths with pathOptions
+type pathValue struct {
+	value   *string
+	options pathOptions
+}
+
+func newPathValue(val string, p *string, options pathOptions) *pathValue {
+	*p = val
+	return &pathValue{value: p, options: options}
+}
+
+func (p *pathValue) Set(s string) error {
+	path, err := p.options.resolve(s)
+	if err != nil {
+		return err
+	}
+	*p.value = path
+	return nil
+}
+
+func (p *pathValue) Get() interface{} { return *p.value }
+
+func (p *pathValue) String() string {
+	if p.value == nil {
+		return ""
+	}
+	return *p.value
+}
+
+// PathFlag is a flag with type string holding a file system path. Expand
+// replaces ~ and environment variables and Absolute makes the path absolute.
+// The paths given through the command line, EnvVars, FilePath or an input
+// source must exist with MustExist, be a file with MustBeFile, a directory
+// with MustBeDir, and be readable or writable with Readable and Writable.
+// Value is only expanded and made absolute.
 type PathFlag struct {
 	Name        string
 	Aliases     []string
@@ -25,6 +192,13 @@ type PathFlag struct {
 	Required    bool
 	Hidden      bool
 	TakesFile   bool
+	Expand      bool
+	Absolute    bool
+	MustExist   bool
+	MustBeFile  bool
+	MustBeDir   bool
+	Readable    bool
+	Writable    bool
 	Value       string
 	DefaultText string
 	Destination *string
@@ -68,19 +242,47 @@ func (f *PathFlag) GetValue() string {
 	return f.Value
 }
 
+func (f *PathFlag) pathOptions() pathOptions {
+	return pathOptions{
+		expand:     f.Expand,
+		absolute:   f.Absolute,
+		mustExist:  f.MustExist,
+		mustBeFile: f.MustBeFile,
+		mustBeDir:  f.MustBeDir,
+		readable:   f.Readable,
+		writable:   f.Writable,
+	}
+}
+
 // Apply populates the flag given the flag set and environment
 func (f *PathFlag) Apply(set *flag.FlagSet) error {
+	options := f.pathOptions()
+	value := f.Value
+	if value != "" {
+		path, err := options.normalize(value)
+		if err != nil {
+			return fmt.Errorf("invalid default value for flag %s: %s", f.Name, err)
+		}
+		value = path
+	}
+
 	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		path, err := options.resolve(val)
+		if err != nil {
+			return fmt.Errorf("could not use %q as path for flag %s: %s", val, f.Name, err)
+		}
+
 		f.Value = val
+		value = path
 		f.HasBeenSet = true
 	}
 
 	for _, name := range f.Names() {
 		if f.Destination != nil {
-			set.StringVar(f.Destination, name, f.Value, f.Usage)
+			set.Var(newPathValue(value, f.Destination, options), name, f.Usage)
 			continue
 		}
-		set.String(name, f.Value, f.Usage)
+		set.Var(newPathValue(value, new(string), options), name, f.Usage)
 	}
 
 	return nil
@@ -108,12 +310,12 @@ func (a *App) pathVar(p *string, name, alias string, value string, usage, env st
 // PathVar defines a string flag with specified name, default value, usage string and env string.
 // The argument p points to a string variable in which to store the value of the flag.
 func (a *App) PathVar(p *string, name string, value string, usage, env string) {
-	a.stringVar(p, name, "", value, usage, env)
+	a.pathVar(p, name, "", value, usage, env)
 }
 
 // PathVarP is like PathVar, but accepts a shorthand letter that can be used after a single dash.
 func (a *App) PathVarP(p *string, name, alias string, value string, usage, env string) {
-	a.stringVar(p, name, alias, value, usage, env)
+	a.pathVar(p, name, alias, value, usage, env)
 }
 
 // PathVar defines a string flag with specified name, default value, usage string and env string.
@@ -0,0 +1,266 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// PathSlice wraps a []string of file system paths to satisfy flag.Value
+type PathSlice struct {
+	value      []string
+	hasBeenSet bool
+	options    pathOptions
+	glob       bool
+	sliceSeparator
+}
+
+// NewPathSlice creates a *PathSlice with default values
+func NewPathSlice(defaults ...string) *PathSlice {
+	return &PathSlice{value: append([]string{}, defaults...)}
+}
+
+// Set resolves the comma separated paths of value and appends them to the
+// list of values, expanding glob patterns when enabled
+func (s *PathSlice) Set(value string) error {
+	if !s.hasBeenSet {
+		s.value = []string{}
+		s.hasBeenSet = true
+	}
+
+	values, overwrite := s.splitValue(value)
+	if overwrite {
+		s.value = []string{}
+	}
+	for _, v := range values {
+		paths, err := s.resolve(v)
+		if err != nil {
+			return err
+		}
+		s.value = append(s.value, paths...)
+	}
+
+	return nil
+}
+
+// resolve normalizes the path, expands it when it is a glob pattern, and
+// checks the resulting paths
+func (s *PathSlice) resolve(path string) ([]string, error) {
+	normalized, err := s.options.normalize(path)
+	if err != nil {
+		return nil, err
+	}
+	if !s.glob || !strings.ContainsAny(normalized, "*?[") {
+		if err := s.options.check(normalized); err != nil {
+			return nil, err
+		}
+		return []string{normalized}, nil
+	}
+
+	matches, err := filepath.Glob(normalized)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %s: %v", s.options.name(strconv.Quote(path)), err)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%s matches no path", s.options.name(path))
+	}
+	for _, match := range matches {
+		if err := s.options.check(match); err != nil {
+			return nil, err
+		}
+	}
+	return matches, nil
+}
+
+// String returns a readable representation of this value (for usage defaults)
+func (s *PathSlice) String() string {
+	return "[" + strings.Join(s.strings(), ",") + "]"
+}
+
+// Serialize allows PathSlice to fulfill Serializer
+func (s *PathSlice) Serialize() string {
+	return serializeSlice(s.Value())
+}
+
+// Value returns the slice of paths set by this flag. The default values are
+// expanded and made absolute as configured, but not checked.
+func (s *PathSlice) Value() []string {
+	if s.hasBeenSet {
+		return s.value
+	}
+	out := make([]string, len(s.value))
+	for i, path := range s.value {
+		normalized, err := s.options.normalize(path)
+		if err != nil {
+			normalized = path
+		}
+		out[i] = normalized
+	}
+	return out
+}
+
+// Get returns the slice of paths set by this flag
+func (s *PathSlice) Get() interface{} {
+	return s.Value()
+}
+
+func (s *PathSlice) strings() []string {
+	if s == nil {
+		return nil
+	}
+	return s.value
+}
+
+// PathSliceFlag is a flag with type *PathSlice. The paths are expanded,
+// made absolute and checked like those of a PathFlag. With Glob, the paths
+// containing *, ? or [ are patterns replaced by the paths they match, which
+// must be at least one.
+type PathSliceFlag struct {
+	Name         string
+	Aliases      []string
+	Usage        string
+	EnvVars      []string
+	FilePath     string
+	Required     bool
+	Hidden       bool
+	Sensitive    bool
+	Expand       bool
+	Absolute     bool
+	MustExist    bool
+	MustBeFile   bool
+	MustBeDir    bool
+	Readable     bool
+	Writable     bool
+	Glob         bool
+	Separator    string
+	DisableSplit bool
+	EnvSeparator string
+	Value        *PathSlice
+	DefaultText  string
+	HasBeenSet   bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *PathSliceFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *PathSliceFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *PathSliceFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *PathSliceFlag) IsRequired() bool {
+	return f.Required
+}
+
+// IsSensitive returns whether or not the flag value must be kept out of
+// help, docs and errors
+func (f *PathSliceFlag) IsSensitive() bool {
+	return f.Sensitive
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *PathSliceFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *PathSliceFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *PathSliceFlag) GetValue() string {
+	if f.Value != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+// isFileFlag marks the value of the flag as a file path
+func (f *PathSliceFlag) isFileFlag() {}
+
+func (f *PathSliceFlag) pathOptions() pathOptions {
+	return pathOptions{
+		expand:     f.Expand,
+		absolute:   f.Absolute,
+		mustExist:  f.MustExist,
+		mustBeFile: f.MustBeFile,
+		mustBeDir:  f.MustBeDir,
+		readable:   f.Readable,
+		writable:   f.Writable,
+		sensitive:  f.Sensitive,
+	}
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *PathSliceFlag) Apply(set *flag.FlagSet) error {
+	sep, envSep := sliceSeparators(f.Separator, f.DisableSplit, f.EnvSeparator)
+	options := f.pathOptions()
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		f.Value = &PathSlice{options: options, glob: f.Glob}
+		f.Value.sliceSeparator = envSep
+
+		if err := f.Value.Set(val); err != nil {
+			return fmt.Errorf("could not use %q as paths for flag %s: %s", errorValue(f, val), f.Name, redactError(f, err, append([]string{val}, envSep.split(val)...)...))
+		}
+
+		f.HasBeenSet = true
+	}
+
+	if f.Value == nil {
+		f.Value = &PathSlice{}
+	}
+	f.Value.options = options
+	f.Value.glob = f.Glob
+	f.Value.sliceSeparator = sep
+	for _, name := range f.Names() {
+		set.Var(f.Value, name, f.Usage)
+	}
+
+	return nil
+}
+
+// PathSlice looks up the value of a local PathSliceFlag, returns
+// nil if not found
+func (c *Context) PathSlice(name string) []string {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return lookupPathSlice(name, fs)
+	}
+	return nil
+}
+
+func lookupPathSlice(name string, set *flag.FlagSet) []string {
+	f := set.Lookup(name)
+	if f != nil {
+		if s, ok := f.Value.(*PathSlice); ok {
+			return s.Value()
+		}
+	}
+	return nil
+}
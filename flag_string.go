@@ -27,6 +27,7 @@ type StringFlag struct {
 	FilePath    string
 	Required    bool
 	Hidden      bool
+	Sensitive   bool
 	TakesFile   bool
 	Value       string
 	DefaultText string
@@ -55,6 +56,12 @@ func (f *StringFlag) IsRequired() bool {
 	return f.Required
 }
 
+// IsSensitive returns whether or not the flag value must be kept out of
+// help, docs and errors
+func (f *StringFlag) IsSensitive() bool {
+	return f.Sensitive
+}
+
 // TakesValue returns true of the flag takes a value, otherwise false
 func (f *StringFlag) TakesValue() bool {
 	return true
@@ -0,0 +1,319 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// StringMap wraps a map[string]string to satisfy flag.Value. It collects
+// key=value pairs, given one per flag or comma separated in one argument.
+type StringMap struct {
+	value               *map[string]string
+	hasBeenSet          bool
+	rejectDuplicateKeys bool
+}
+
+// NewStringMap creates a *StringMap with default values
+func NewStringMap(defaults map[string]string) *StringMap {
+	return newStringMap(defaults, nil)
+}
+
+func newStringMap(value map[string]string, p *map[string]string) *StringMap {
+	m := new(StringMap)
+	if p == nil {
+		p = &map[string]string{}
+	}
+	m.value = p
+	*m.value = copyStringMap(value)
+	return m
+}
+
+// Set adds the key=value pairs of value to the map. The first pair set
+// replaces the default values.
+func (m *StringMap) Set(value string) error {
+	if m.value == nil {
+		m.value = &map[string]string{}
+	}
+	if !m.hasBeenSet {
+		*m.value = map[string]string{}
+		m.hasBeenSet = true
+	}
+
+	if strings.HasPrefix(value, slPfx) {
+		// Deserializing assumes overwrite
+		values := map[string]string{}
+		_ = json.Unmarshal([]byte(strings.Replace(value, slPfx, "", 1)), &values)
+		*m.value = values
+		return nil
+	}
+
+	pairs, err := keyValueConv(value)
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range pairs {
+		if _, ok := (*m.value)[pair[0]]; ok && m.rejectDuplicateKeys {
+			return fmt.Errorf("duplicate key %q", pair[0])
+		}
+		(*m.value)[pair[0]] = pair[1]
+	}
+
+	return nil
+}
+
+// keyValueConv splits comma separated key=value pairs
+func keyValueConv(val string) ([][2]string, error) {
+	if strings.TrimSpace(val) == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(val, ",")
+	pairs := make([][2]string, 0, len(parts))
+	for _, part := range parts {
+		kv := strings.SplitN(part, "=", 2)
+		key := strings.TrimSpace(kv[0])
+		if len(kv) != 2 || key == "" {
+			return nil, fmt.Errorf("expected key=value, got %q", strings.TrimSpace(part))
+		}
+		pairs = append(pairs, [2]string{key, strings.TrimSpace(kv[1])})
+	}
+	return pairs, nil
+}
+
+// keyValueStrings returns val with its pairs and values, the strings an
+// error about val may quote
+func keyValueStrings(val string) []string {
+	strs := []string{val}
+	for _, part := range strings.Split(val, ",") {
+		strs = append(strs, strings.TrimSpace(part))
+		if kv := strings.SplitN(part, "=", 2); len(kv) == 2 {
+			strs = append(strs, strings.TrimSpace(kv[1]))
+		}
+	}
+	return strs
+}
+
+// String returns a readable representation of this value (for usage defaults)
+func (m *StringMap) String() string {
+	if m.value == nil {
+		return ""
+	}
+	return strings.Join(stringMapPairs(*m.value), ",")
+}
+
+// Serialize allows StringMap to fulfill Serializer
+func (m *StringMap) Serialize() string {
+	jsonBytes, _ := json.Marshal(*m.value)
+	return fmt.Sprintf("%s%s", slPfx, string(jsonBytes))
+}
+
+// Value returns the map of strings set by this flag
+func (m *StringMap) Value() map[string]string {
+	if m.value == nil {
+		m.value = &map[string]string{}
+	}
+	return *m.value
+}
+
+// Get returns the map of strings set by this flag
+func (m *StringMap) Get() interface{} {
+	return m.Value()
+}
+
+// stringMapPairs returns the key=value pairs of m sorted by key
+func stringMapPairs(m map[string]string) []string {
+	pairs := make([]string, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return pairs
+}
+
+func copyStringMap(m map[string]string) map[string]string {
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// StringMapFlag is a flag with type *StringMap
+type StringMapFlag struct {
+	Name                string
+	Aliases             []string
+	Usage               string
+	EnvVars             []string
+	FilePath            string
+	Required            bool
+	Hidden              bool
+	Sensitive           bool
+	RejectDuplicateKeys bool
+	Value               *StringMap
+	DefaultText         string
+	HasBeenSet          bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *StringMapFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *StringMapFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *StringMapFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *StringMapFlag) IsRequired() bool {
+	return f.Required
+}
+
+// IsSensitive returns whether or not the flag value must be kept out of
+// help, docs and errors
+func (f *StringMapFlag) IsSensitive() bool {
+	return f.Sensitive
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *StringMapFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *StringMapFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *StringMapFlag) GetValue() string {
+	if f.Value != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+// Apply populates the flag given the flag set and environment. Values given
+// on the command line replace the ones from the environment.
+func (f *StringMapFlag) Apply(set *flag.FlagSet) error {
+	if f.Value == nil {
+		f.Value = &StringMap{}
+	}
+	f.Value.rejectDuplicateKeys = f.RejectDuplicateKeys
+
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		f.Value.hasBeenSet = false
+		if err := f.Value.Set(val); err != nil {
+			return fmt.Errorf("could not parse %q as key=value pairs for flag %s: %s", errorValue(f, val), f.Name, redactError(f, err, keyValueStrings(val)...))
+		}
+
+		// the command line replaces the values of the environment
+		f.Value.hasBeenSet = false
+		f.HasBeenSet = true
+	}
+
+	for _, name := range f.Names() {
+		set.Var(f.Value, name, f.Usage)
+	}
+
+	return nil
+}
+
+func (a *App) stringMapVar(p *map[string]string, name, alias string, value map[string]string, usage, env string) {
+	if a.Flags == nil {
+		a.Flags = make([]Flag, 0)
+	}
+	flag := &StringMapFlag{
+		Name:  name,
+		Usage: usage,
+		Value: newStringMap(value, p),
+	}
+	if alias != "" {
+		flag.Aliases = []string{alias}
+	}
+	if env != "" {
+		flag.EnvVars = []string{env}
+	}
+	a.Flags = append(a.Flags, flag)
+}
+
+// StringMapVar defines a map[string]string flag with specified name, default value, usage string and env string.
+// The argument p points to a map[string]string variable in which to store the value of the flag.
+func (a *App) StringMapVar(p *map[string]string, name string, value map[string]string, usage, env string) {
+	a.stringMapVar(p, name, "", value, usage, env)
+}
+
+// StringMapVarP is like StringMapVar, but accepts a shorthand letter that can be used after a single dash.
+func (a *App) StringMapVarP(p *map[string]string, name, alias string, value map[string]string, usage, env string) {
+	a.stringMapVar(p, name, alias, value, usage, env)
+}
+
+// StringMapVar defines a map[string]string flag with specified name, default value, usage string and env string.
+// The argument p points to a map[string]string variable in which to store the value of the flag.
+func StringMapVar(p *map[string]string, name string, value map[string]string, usage, env string) {
+	CommandLine.StringMapVar(p, name, value, usage, env)
+}
+
+// StringMapVarP is like StringMapVar, but accepts a shorthand letter that can be used after a single dash.
+func StringMapVarP(p *map[string]string, name, alias string, value map[string]string, usage, env string) {
+	CommandLine.StringMapVarP(p, name, alias, value, usage, env)
+}
+
+// StringMap defines a map[string]string flag with specified name, default value, usage string and env string.
+// The return value is the address of a map[string]string variable that stores the value of the flag.
+func (a *App) StringMap(name string, value map[string]string, usage, env string) *map[string]string {
+	p := new(map[string]string)
+	a.StringMapVar(p, name, value, usage, env)
+	return p
+}
+
+// StringMapP is like StringMap, but accepts a shorthand letter that can be used after a single dash.
+func (a *App) StringMapP(name, alias string, value map[string]string, usage, env string) *map[string]string {
+	p := new(map[string]string)
+	a.StringMapVarP(p, name, alias, value, usage, env)
+	return p
+}
+
+// StringMap looks up the value of a local StringMapFlag, returns
+// nil if not found
+func (c *Context) StringMap(name string) map[string]string {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return lookupStringMap(name, fs)
+	}
+	return nil
+}
+
+func lookupStringMap(name string, set *flag.FlagSet) map[string]string {
+	f := set.Lookup(name)
+	if f != nil {
+		if m, ok := f.Value.(*StringMap); ok {
+			return m.Value()
+		}
+	}
+	return nil
+}
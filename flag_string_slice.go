@@ -25,6 +25,7 @@ import (
 type StringSlice struct {
 	value      *[]string
 	hasBeenSet bool
+	sliceSeparator
 }
 
 // NewStringSlice creates a *StringSlice with default values
@@ -56,7 +57,7 @@ func (s *StringSlice) Set(value string) error {
 		return nil
 	}
 
-	tmp, err := stringSliceConv(value)
+	tmp, err := stringSliceConv(s.splitList(value))
 	if err != nil {
 		return err
 	}
@@ -66,17 +67,9 @@ func (s *StringSlice) Set(value string) error {
 	return nil
 }
 
-func stringSliceConv(val string) ([]string, error) {
-	val = strings.Trim(val, "[]")
-	// Empty string would cause a slice with one (empty) entry
-	if len(val) == 0 {
-		return []string{}, nil
-	}
-	ss := strings.Split(val, ",")
+func stringSliceConv(ss []string) ([]string, error) {
 	out := make([]string, len(ss))
-	for i, s := range ss {
-		out[i] = strings.TrimSpace(s)
-	}
+	copy(out, ss)
 	return out, nil
 }
 
@@ -113,17 +106,21 @@ func (s *StringSlice) Get() interface{} {
 
 // StringSliceFlag is a flag with type *StringSlice
 type StringSliceFlag struct {
-	Name        string
-	Aliases     []string
-	Usage       string
-	EnvVars     []string
-	FilePath    string
-	Required    bool
-	Hidden      bool
-	TakesFile   bool
-	Value       *StringSlice
-	DefaultText string
-	HasBeenSet  bool
+	Name         string
+	Aliases      []string
+	Usage        string
+	EnvVars      []string
+	FilePath     string
+	Required     bool
+	Hidden       bool
+	Sensitive    bool
+	TakesFile    bool
+	Separator    string
+	DisableSplit bool
+	EnvSeparator string
+	Value        *StringSlice
+	DefaultText  string
+	HasBeenSet   bool
 }
 
 // IsSet returns whether or not the flag has been set through env or file
@@ -147,6 +144,12 @@ func (f *StringSliceFlag) IsRequired() bool {
 	return f.Required
 }
 
+// IsSensitive returns whether or not the flag value must be kept out of
+// help, docs and errors
+func (f *StringSliceFlag) IsSensitive() bool {
+	return f.Sensitive
+}
+
 // TakesValue returns true of the flag takes a value, otherwise false
 func (f *StringSliceFlag) TakesValue() bool {
 	return true
@@ -168,22 +171,23 @@ func (f *StringSliceFlag) GetValue() string {
 
 // Apply populates the flag given the flag set and environment
 func (f *StringSliceFlag) Apply(set *flag.FlagSet) error {
+	sep, envSep := sliceSeparators(f.Separator, f.DisableSplit, f.EnvSeparator)
 	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
 		f.Value = &StringSlice{}
+		f.Value.sliceSeparator = envSep
 
-		for _, s := range strings.Split(val, ",") {
-			if err := f.Value.Set(strings.TrimSpace(s)); err != nil {
-				return fmt.Errorf("could not parse %q as string value for flag %s: %s", val, f.Name, err)
-			}
+		if err := f.Value.Set(val); err != nil {
+			return fmt.Errorf("could not parse %q as string value for flag %s: %s", errorValue(f, val), f.Name, redactError(f, err, append([]string{val}, envSep.split(val)...)...))
 		}
 
 		f.HasBeenSet = true
 	}
 
+	if f.Value == nil {
+		f.Value = &StringSlice{}
+	}
+	f.Value.sliceSeparator = sep
 	for _, name := range f.Names() {
-		if f.Value == nil {
-			f.Value = &StringSlice{}
-		}
 		set.Var(f.Value, name, f.Usage)
 	}
 
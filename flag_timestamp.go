@@ -17,14 +17,21 @@ package cli
 import (
 	"flag"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// timeNow returns the current time relative times are computed from
+var timeNow = time.Now
+
 // Timestamp wrap to satisfy golang's flag interface.
 type Timestamp struct {
 	timestamp  *time.Time
 	hasBeenSet bool
 	layout     string
+	layouts    []string
+	location   *time.Location
 }
 
 // Timestamp constructor
@@ -45,8 +52,7 @@ func newTimestamp(timestamp time.Time, p *time.Time) *Timestamp {
 // Set the timestamp value directly
 func (t *Timestamp) SetTimestamp(value time.Time) {
 	if !t.hasBeenSet {
-		t.timestamp = &value
-		t.hasBeenSet = true
+		t.setTimestamp(value)
 	}
 }
 
@@ -55,21 +61,51 @@ func (t *Timestamp) SetLayout(layout string) {
 	t.layout = layout
 }
 
-// Parses the string value to timestamp
+// SetLayouts sets more layouts for future parsing, tried in order after the
+// one of SetLayout
+func (t *Timestamp) SetLayouts(layouts ...string) {
+	t.layouts = layouts
+}
+
+// SetLocation sets the time zone of timestamps parsed without one and of
+// relative times, UTC when nil
+func (t *Timestamp) SetLocation(location *time.Location) {
+	t.location = location
+}
+
+// Parses the string value to timestamp. Besides the layouts, the value may
+// be given in RFC3339, as Unix epoch seconds prefixed with @ like
+// @1600000000, or as a relative time like now, now-2h, today, yesterday+9h
+// or now-7d.
 func (t *Timestamp) Set(value string) error {
-	timestamp, err := time.Parse(t.layout, value)
+	layouts := t.layouts
+	if t.layout != "" {
+		layouts = append([]string{t.layout}, layouts...)
+	}
+
+	timestamp, err := parseTimestamp(value, layouts, t.location)
 	if err != nil {
 		return err
 	}
 
-	t.timestamp = &timestamp
-	t.hasBeenSet = true
+	t.setTimestamp(timestamp)
 	return nil
 }
 
+func (t *Timestamp) setTimestamp(value time.Time) {
+	if t.timestamp == nil {
+		t.timestamp = new(time.Time)
+	}
+	*t.timestamp = value
+	t.hasBeenSet = true
+}
+
 // String returns a readable representation of this value (for usage defaults)
 func (t *Timestamp) String() string {
-	return fmt.Sprintf("%#v", t.timestamp)
+	if t.timestamp == nil {
+		return ""
+	}
+	return t.timestamp.Format(time.RFC3339Nano)
 }
 
 // Value returns the timestamp value stored in the flag
@@ -82,7 +118,111 @@ func (t *Timestamp) Get() interface{} {
 	return *t
 }
 
-// TimestampFlag is a flag with type time
+// parseTimestamp parses value as a relative time, as Unix epoch seconds
+// prefixed with @, or with the first of the layouts matching, then as
+// RFC3339. Timestamps without a time zone are in location, UTC when nil.
+func parseTimestamp(value string, layouts []string, location *time.Location) (time.Time, error) {
+	if location == nil {
+		location = time.UTC
+	}
+	value = strings.TrimSpace(value)
+
+	if timestamp, ok, err := parseRelativeTime(value, location); ok {
+		return timestamp, err
+	}
+	if strings.HasPrefix(value, "@") {
+		timestamp, ok := parseEpoch(value[1:])
+		if !ok {
+			return time.Time{}, fmt.Errorf("cannot parse %q as Unix epoch seconds", value)
+		}
+		return timestamp.In(location), nil
+	}
+
+	var layoutErr error
+	for _, layout := range layouts {
+		timestamp, err := time.ParseInLocation(layout, value, location)
+		if err == nil {
+			return timestamp, nil
+		}
+		if layoutErr == nil {
+			layoutErr = err
+		}
+	}
+
+	if timestamp, err := time.ParseInLocation(time.RFC3339, value, location); err == nil {
+		return timestamp, nil
+	}
+
+	if layoutErr != nil {
+		return time.Time{}, layoutErr
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as timestamp, expected RFC3339, Unix epoch seconds like @1600000000 or a relative time like now-2h", value)
+}
+
+// parseRelativeTime parses now, today, yesterday and tomorrow, optionally
+// followed by an offset like -2h30m or +7d. ok is false when value is not a
+// relative time.
+func parseRelativeTime(value string, location *time.Location) (timestamp time.Time, ok bool, err error) {
+	base, offset := value, ""
+	if i := strings.IndexAny(value, "+-"); i > 0 {
+		base, offset = value[:i], value[i:]
+	}
+
+	now := timeNow().In(location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+	switch strings.ToLower(base) {
+	case "now":
+		timestamp = now
+	case "today":
+		timestamp = today
+	case "yesterday":
+		timestamp = today.AddDate(0, 0, -1)
+	case "tomorrow":
+		timestamp = today.AddDate(0, 0, 1)
+	default:
+		return time.Time{}, false, nil
+	}
+
+	if offset == "" {
+		return timestamp, true, nil
+	}
+	if days, err := strconv.Atoi(strings.TrimSuffix(offset, "d")); err == nil && strings.HasSuffix(offset, "d") {
+		return timestamp.AddDate(0, 0, days), true, nil
+	}
+	d, err := time.ParseDuration(offset)
+	if err != nil {
+		return time.Time{}, true, fmt.Errorf("invalid offset %q in relative time %q", offset, value)
+	}
+	return timestamp.Add(d), true, nil
+}
+
+// parseEpoch parses Unix epoch seconds with an optional fraction
+func parseEpoch(value string) (time.Time, bool) {
+	parts := strings.SplitN(value, ".", 2)
+	sec, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var nsec int64
+	if len(parts) == 2 {
+		frac := parts[1]
+		if frac == "" || strings.Trim(frac, "0123456789") != "" {
+			return time.Time{}, false
+		}
+		frac = (frac + "000000000")[:9]
+		nsec, _ = strconv.ParseInt(frac, 10, 64)
+		if strings.HasPrefix(parts[0], "-") {
+			nsec = -nsec
+		}
+	}
+	return time.Unix(sec, nsec), true
+}
+
+// TimestampFlag is a flag with type time. The value is parsed with Layout
+// and Layouts, as RFC3339, as Unix epoch seconds prefixed with @ or as a
+// relative time, see Timestamp.Set. Timezone is the time zone of timestamps
+// given without one and of relative times, UTC when nil.
 type TimestampFlag struct {
 	Name        string
 	Aliases     []string
@@ -92,8 +232,11 @@ type TimestampFlag struct {
 	Required    bool
 	Hidden      bool
 	Layout      string
+	Layouts     []string
+	Timezone    *time.Location
 	Value       *Timestamp
 	DefaultText string
+	Destination *time.Time
 	HasBeenSet  bool
 }
 
@@ -131,19 +274,36 @@ func (f *TimestampFlag) GetUsage() string {
 // GetValue returns the flags value as string representation and an empty
 // string if the flag takes no value at all.
 func (f *TimestampFlag) GetValue() string {
-	if f.Value != nil {
-		return f.Value.timestamp.String()
+	if f.Value == nil || f.Value.timestamp == nil || f.Value.timestamp.IsZero() {
+		return ""
 	}
-	return ""
+
+	layout := f.Layout
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	timestamp := *f.Value.timestamp
+	if f.Timezone != nil {
+		timestamp = timestamp.In(f.Timezone)
+	}
+	return timestamp.Format(layout)
 }
 
 // Apply populates the flag given the flag set and environment
 func (f *TimestampFlag) Apply(set *flag.FlagSet) error {
-	if f.Layout == "" {
-		return fmt.Errorf("timestamp Layout is required")
+	if f.Value == nil {
+		f.Value = &Timestamp{}
 	}
-	f.Value = &Timestamp{}
 	f.Value.SetLayout(f.Layout)
+	f.Value.SetLayouts(f.Layouts...)
+	f.Value.SetLocation(f.Timezone)
+
+	if f.Destination != nil {
+		if f.Value.timestamp != nil {
+			*f.Destination = *f.Value.timestamp
+		}
+		f.Value.timestamp = f.Destination
+	}
 
 	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
 		if err := f.Value.Set(val); err != nil {
@@ -225,7 +385,9 @@ func (c *Context) Timestamp(name string) *time.Time {
 func lookupTimestamp(name string, set *flag.FlagSet) *time.Time {
 	f := set.Lookup(name)
 	if f != nil {
-		return (f.Value.(*Timestamp)).Value()
+		if t, ok := f.Value.(*Timestamp); ok {
+			return t.Value()
+		}
 	}
 	return nil
 }
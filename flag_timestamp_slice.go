@@ -0,0 +1,254 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimestampSlice wraps []time.Time to satisfy flag.Value
+type TimestampSlice struct {
+	value      *[]time.Time
+	hasBeenSet bool
+	layout     string
+	layouts    []string
+	location   *time.Location
+	sliceSeparator
+}
+
+// NewTimestampSlice makes a *TimestampSlice with default values
+func NewTimestampSlice(defaults ...time.Time) *TimestampSlice {
+	p := append([]time.Time{}, defaults...)
+	return &TimestampSlice{value: &p}
+}
+
+// SetLayout sets the timestamp layout used to parse and print the values
+func (t *TimestampSlice) SetLayout(layout string) {
+	t.layout = layout
+}
+
+// SetLayouts sets more layouts for parsing, tried in order after the one of
+// SetLayout
+func (t *TimestampSlice) SetLayouts(layouts ...string) {
+	t.layouts = layouts
+}
+
+// SetLocation sets the time zone of timestamps parsed without one and of
+// relative times, UTC when nil
+func (t *TimestampSlice) SetLocation(location *time.Location) {
+	t.location = location
+}
+
+// Set parses the value into timestamps and appends them to the list of
+// values, each one parsed like Timestamp.Set. Without a separator
+// configured, values are comma separated unless the layout contains a comma
+// itself.
+func (t *TimestampSlice) Set(value string) error {
+	if t.value == nil {
+		t.value = &[]time.Time{}
+	}
+	if !t.hasBeenSet {
+		*t.value = []time.Time{}
+		t.hasBeenSet = true
+	}
+
+	if strings.HasPrefix(value, slPfx) {
+		// Deserializing assumes overwrite
+		_ = json.Unmarshal([]byte(strings.Replace(value, slPfx, "", 1)), &(*t.value))
+		return nil
+	}
+
+	sep := t.sliceSeparator
+	if sep.separator == "" && strings.Contains(t.layout, ",") {
+		sep.disableSplit = true
+	}
+	layouts := append([]string{t.layout}, t.layouts...)
+	for _, v := range sep.split(value) {
+		timestamp, err := parseTimestamp(v, layouts, t.location)
+		if err != nil {
+			return err
+		}
+		*t.value = append(*t.value, timestamp)
+	}
+
+	return nil
+}
+
+// setOptions sets the layouts and the time zone of f
+func (t *TimestampSlice) setOptions(f *TimestampSliceFlag) {
+	t.SetLayout(f.Layout)
+	t.SetLayouts(f.Layouts...)
+	t.SetLocation(f.Timezone)
+}
+
+// String returns a readable representation of this value (for usage defaults)
+func (t *TimestampSlice) String() string {
+	return "[" + strings.Join(t.strings(), ",") + "]"
+}
+
+// Serialize allows TimestampSlice to fulfill Serializer
+func (t *TimestampSlice) Serialize() string {
+	jsonBytes, _ := json.Marshal(t.Value())
+	return fmt.Sprintf("%s%s", slPfx, string(jsonBytes))
+}
+
+// Value returns the slice of timestamps set by this flag
+func (t *TimestampSlice) Value() []time.Time {
+	if t.value == nil {
+		t.value = &[]time.Time{}
+	}
+	return *t.value
+}
+
+// Get returns the slice of timestamps set by this flag
+func (t *TimestampSlice) Get() interface{} {
+	return t.Value()
+}
+
+// strings returns the values formatted with the layout
+func (t *TimestampSlice) strings() []string {
+	if t == nil || t.value == nil {
+		return nil
+	}
+	out := make([]string, len(*t.value))
+	for i, timestamp := range *t.value {
+		out[i] = timestamp.Format(t.layout)
+	}
+	return out
+}
+
+// TimestampSliceFlag is a flag with type *TimestampSlice. The values are
+// parsed like the value of a TimestampFlag, with Layout, Layouts and
+// Timezone.
+type TimestampSliceFlag struct {
+	Name         string
+	Aliases      []string
+	Usage        string
+	EnvVars      []string
+	FilePath     string
+	Required     bool
+	Hidden       bool
+	Sensitive    bool
+	Layout       string
+	Layouts      []string
+	Timezone     *time.Location
+	Separator    string
+	DisableSplit bool
+	EnvSeparator string
+	Value        *TimestampSlice
+	DefaultText  string
+	HasBeenSet   bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *TimestampSliceFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *TimestampSliceFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *TimestampSliceFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *TimestampSliceFlag) IsRequired() bool {
+	return f.Required
+}
+
+// IsSensitive returns whether or not the flag value must be kept out of
+// help, docs and errors
+func (f *TimestampSliceFlag) IsSensitive() bool {
+	return f.Sensitive
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *TimestampSliceFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *TimestampSliceFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *TimestampSliceFlag) GetValue() string {
+	if f.Value != nil {
+		f.Value.SetLayout(f.Layout)
+		return f.Value.String()
+	}
+	return ""
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *TimestampSliceFlag) Apply(set *flag.FlagSet) error {
+	sep, envSep := sliceSeparators(f.Separator, f.DisableSplit, f.EnvSeparator)
+	if f.Layout == "" {
+		return fmt.Errorf("timestamp Layout is required")
+	}
+
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		f.Value = &TimestampSlice{}
+		f.Value.setOptions(f)
+		f.Value.sliceSeparator = envSep
+
+		if err := f.Value.Set(val); err != nil {
+			return fmt.Errorf("could not parse %q as timestamp slice value for flag %s: %s", errorValue(f, val), f.Name, redactError(f, err, append([]string{val}, envSep.split(val)...)...))
+		}
+
+		f.HasBeenSet = true
+	}
+
+	if f.Value == nil {
+		f.Value = &TimestampSlice{}
+	}
+	f.Value.sliceSeparator = sep
+	f.Value.setOptions(f)
+	for _, name := range f.Names() {
+		set.Var(f.Value, name, f.Usage)
+	}
+
+	return nil
+}
+
+// TimestampSlice looks up the value of a local TimestampSliceFlag, returns
+// nil if not found
+func (c *Context) TimestampSlice(name string) []time.Time {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return lookupTimestampSlice(name, fs)
+	}
+	return nil
+}
+
+func lookupTimestampSlice(name string, set *flag.FlagSet) []time.Time {
+	f := set.Lookup(name)
+	if f != nil {
+		if t, ok := f.Value.(*TimestampSlice); ok {
+			return t.Value()
+		}
+	}
+	return nil
+}
@@ -20,7 +20,36 @@ import (
 	"strconv"
 )
 
-// UintFlag is a flag with type uint
+// uintValue is a flag.Value parsing uint values, with SI suffixes when enabled
+type uintValue struct {
+	value      *uint
+	siSuffixes bool
+}
+
+func newUintValue(val uint, p *uint, siSuffixes bool) *uintValue {
+	*p = val
+	return &uintValue{value: p, siSuffixes: siSuffixes}
+}
+
+func (v *uintValue) Set(s string) error {
+	n, err := parseUint(s, strconv.IntSize, v.siSuffixes)
+	if err != nil {
+		return err
+	}
+	*v.value = uint(n)
+	return nil
+}
+
+func (v *uintValue) Get() interface{} { return *v.value }
+
+func (v *uintValue) String() string {
+	if v.value == nil {
+		return "0"
+	}
+	return strconv.FormatUint(uint64(*v.value), 10)
+}
+
+// UintFlag is a flag with type uint, parsing values like an IntFlag
 type UintFlag struct {
 	Name        string
 	Aliases     []string
@@ -29,6 +58,7 @@ type UintFlag struct {
 	FilePath    string
 	Required    bool
 	Hidden      bool
+	SISuffixes  bool
 	Value       uint
 	DefaultText string
 	Destination *uint
@@ -70,7 +100,7 @@ func (f *UintFlag) GetUsage() string {
 func (f *UintFlag) Apply(set *flag.FlagSet) error {
 	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
 		if val != "" {
-			valInt, err := strconv.ParseUint(val, 0, 64)
+			valInt, err := parseUint(val, strconv.IntSize, f.SISuffixes)
 			if err != nil {
 				return fmt.Errorf("could not parse %q as uint value for flag %s: %s", val, f.Name, err)
 			}
@@ -82,10 +112,10 @@ func (f *UintFlag) Apply(set *flag.FlagSet) error {
 
 	for _, name := range f.Names() {
 		if f.Destination != nil {
-			set.UintVar(f.Destination, name, f.Value, f.Usage)
+			set.Var(newUintValue(f.Value, f.Destination, f.SISuffixes), name, f.Usage)
 			continue
 		}
-		set.Uint(name, f.Value, f.Usage)
+		set.Var(newUintValue(f.Value, new(uint), f.SISuffixes), name, f.Usage)
 	}
 
 	return nil
@@ -20,7 +20,36 @@ import (
 	"strconv"
 )
 
-// Uint64Flag is a flag with type uint64
+// uint64Value is a flag.Value parsing uint64 values, with SI suffixes when enabled
+type uint64Value struct {
+	value      *uint64
+	siSuffixes bool
+}
+
+func newUint64Value(val uint64, p *uint64, siSuffixes bool) *uint64Value {
+	*p = val
+	return &uint64Value{value: p, siSuffixes: siSuffixes}
+}
+
+func (v *uint64Value) Set(s string) error {
+	n, err := parseUint(s, 64, v.siSuffixes)
+	if err != nil {
+		return err
+	}
+	*v.value = n
+	return nil
+}
+
+func (v *uint64Value) Get() interface{} { return *v.value }
+
+func (v *uint64Value) String() string {
+	if v.value == nil {
+		return "0"
+	}
+	return strconv.FormatUint(*v.value, 10)
+}
+
+// Uint64Flag is a flag with type uint64, parsing values like an IntFlag
 type Uint64Flag struct {
 	Name        string
 	Aliases     []string
@@ -29,6 +58,7 @@ type Uint64Flag struct {
 	FilePath    string
 	Required    bool
 	Hidden      bool
+	SISuffixes  bool
 	Value       uint64
 	DefaultText string
 	Destination *uint64
@@ -70,7 +100,7 @@ func (f *Uint64Flag) GetUsage() string {
 func (f *Uint64Flag) Apply(set *flag.FlagSet) error {
 	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
 		if val != "" {
-			valInt, err := strconv.ParseUint(val, 0, 64)
+			valInt, err := parseUint(val, 64, f.SISuffixes)
 			if err != nil {
 				return fmt.Errorf("could not parse %q as uint64 value for flag %s: %s", val, f.Name, err)
 			}
@@ -82,10 +112,10 @@ func (f *Uint64Flag) Apply(set *flag.FlagSet) error {
 
 	for _, name := range f.Names() {
 		if f.Destination != nil {
-			set.Uint64Var(f.Destination, name, f.Value, f.Usage)
+			set.Var(newUint64Value(f.Value, f.Destination, f.SISuffixes), name, f.Usage)
 			continue
 		}
-		set.Uint64(name, f.Value, f.Usage)
+		set.Var(newUint64Value(f.Value, new(uint64), f.SISuffixes), name, f.Usage)
 	}
 
 	return nil
@@ -0,0 +1,277 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Uint64Slice wraps []uint64 to satisfy flag.Value
+type Uint64Slice struct {
+	value      *[]uint64
+	hasBeenSet bool
+	sliceSeparator
+}
+
+// NewUint64Slice makes an *Uint64Slice with default values
+func NewUint64Slice(defaults ...uint64) *Uint64Slice {
+	return newUint64Slice(defaults, nil)
+}
+
+func newUint64Slice(value []uint64, p *[]uint64) *Uint64Slice {
+	slice := new(Uint64Slice)
+	if p == nil {
+		p = &[]uint64{}
+	}
+	slice.value = p
+	*slice.value = value
+	return slice
+}
+
+// Set parses the value into an unsigned integer and appends it to the list of values
+func (i *Uint64Slice) Set(value string) error {
+	if i.value == nil {
+		i.value = &[]uint64{}
+	}
+	if !i.hasBeenSet {
+		*i.value = []uint64{}
+		i.hasBeenSet = true
+	}
+
+	if strings.HasPrefix(value, slPfx) {
+		// Deserializing assumes overwrite
+		_ = json.Unmarshal([]byte(strings.Replace(value, slPfx, "", 1)), &(*i.value))
+		i.hasBeenSet = true
+		return nil
+	}
+
+	tmp, err := uint64SliceConv(i.splitList(value))
+	if err != nil {
+		return err
+	}
+	*i.value = append(*i.value, tmp...)
+
+	return nil
+}
+
+func uint64SliceConv(ss []string) ([]uint64, error) {
+	out := make([]uint64, len(ss))
+	for i, d := range ss {
+		var err error
+		out[i], err = strconv.ParseUint(strings.TrimSpace(d), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// String returns a readable representation of this value (for usage defaults)
+func (i *Uint64Slice) String() string {
+	if i.value == nil {
+		return "[]"
+	}
+	out := make([]string, len(*i.value))
+	for i, d := range *i.value {
+		out[i] = fmt.Sprintf("%d", d)
+	}
+	return "[" + strings.Join(out, ",") + "]"
+}
+
+// Serialize allows Uint64Slice to fulfill Serializer
+func (i *Uint64Slice) Serialize() string {
+	jsonBytes, _ := json.Marshal(*i.value)
+	return fmt.Sprintf("%s%s", slPfx, string(jsonBytes))
+}
+
+// Value returns the slice of unsigned ints set by this flag
+func (i *Uint64Slice) Value() []uint64 {
+	if i.value == nil {
+		i.value = &[]uint64{}
+	}
+	return *i.value
+}
+
+// Get returns the slice of unsigned ints set by this flag
+func (i *Uint64Slice) Get() interface{} {
+	return *i
+}
+
+// Uint64SliceFlag is a flag with type *Uint64Slice
+type Uint64SliceFlag struct {
+	Name         string
+	Aliases      []string
+	Usage        string
+	EnvVars      []string
+	FilePath     string
+	Required     bool
+	Hidden       bool
+	Sensitive    bool
+	Separator    string
+	DisableSplit bool
+	EnvSeparator string
+	Value        *Uint64Slice
+	DefaultText  string
+	HasBeenSet   bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *Uint64SliceFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *Uint64SliceFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *Uint64SliceFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *Uint64SliceFlag) IsRequired() bool {
+	return f.Required
+}
+
+// IsSensitive returns whether or not the flag value must be kept out of
+// help, docs and errors
+func (f *Uint64SliceFlag) IsSensitive() bool {
+	return f.Sensitive
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *Uint64SliceFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *Uint64SliceFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *Uint64SliceFlag) GetValue() string {
+	if f.Value != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *Uint64SliceFlag) Apply(set *flag.FlagSet) error {
+	sep, envSep := sliceSeparators(f.Separator, f.DisableSplit, f.EnvSeparator)
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		f.Value = &Uint64Slice{}
+		f.Value.sliceSeparator = envSep
+
+		if err := f.Value.Set(val); err != nil {
+			return fmt.Errorf("could not parse %q as uint64 slice value for flag %s: %s", errorValue(f, val), f.Name, redactError(f, err, append([]string{val}, envSep.split(val)...)...))
+		}
+
+		f.HasBeenSet = true
+	}
+
+	if f.Value == nil {
+		f.Value = &Uint64Slice{}
+	}
+	f.Value.sliceSeparator = sep
+	for _, name := range f.Names() {
+		set.Var(f.Value, name, f.Usage)
+	}
+
+	return nil
+}
+
+func (a *App) uint64SliceVar(p *[]uint64, name, alias string, value []uint64, usage, env string) {
+	if a.Flags == nil {
+		a.Flags = make([]Flag, 0)
+	}
+	flag := &Uint64SliceFlag{
+		Name:  name,
+		Usage: usage,
+		Value: newUint64Slice(value, p),
+	}
+	if alias != "" {
+		flag.Aliases = []string{alias}
+	}
+	if env != "" {
+		flag.EnvVars = []string{env}
+	}
+	a.Flags = append(a.Flags, flag)
+}
+
+// Uint64SliceVar defines a []uint64 flag with specified name, default value, usage string and env string.
+// The argument p points to a []uint64 variable in which to store the value of the flag.
+func (a *App) Uint64SliceVar(p *[]uint64, name string, value []uint64, usage, env string) {
+	a.uint64SliceVar(p, name, "", value, usage, env)
+}
+
+// Uint64SliceVarP is like Uint64SliceVar, but accepts a shorthand letter that can be used after a single dash.
+func (a *App) Uint64SliceVarP(p *[]uint64, name, alias string, value []uint64, usage, env string) {
+	a.uint64SliceVar(p, name, alias, value, usage, env)
+}
+
+// Uint64SliceVar defines a []uint64 flag with specified name, default value, usage string and env string.
+// The argument p points to a []uint64 variable in which to store the value of the flag.
+func Uint64SliceVar(p *[]uint64, name string, value []uint64, usage, env string) {
+	CommandLine.Uint64SliceVar(p, name, value, usage, env)
+}
+
+// Uint64SliceVarP is like Uint64SliceVar, but accepts a shorthand letter that can be used after a single dash.
+func Uint64SliceVarP(p *[]uint64, name, alias string, value []uint64, usage, env string) {
+	CommandLine.Uint64SliceVarP(p, name, alias, value, usage, env)
+}
+
+// Uint64Slice defines a []uint64 flag with specified name, default value, usage string and env string.
+// The return value is the address of a []uint64 variable that stores the value of the flag.
+func (a *App) Uint64Slice(name string, value []uint64, usage, env string) *[]uint64 {
+	p := new([]uint64)
+	a.Uint64SliceVar(p, name, value, usage, env)
+	return p
+}
+
+// Uint64SliceP is like Uint64Slice, but accepts a shorthand letter that can be used after a single dash.
+func (a *App) Uint64SliceP(name, alias string, value []uint64, usage, env string) *[]uint64 {
+	p := new([]uint64)
+	a.Uint64SliceVarP(p, name, alias, value, usage, env)
+	return p
+}
+
+// Uint64Slice looks up the value of a local Uint64SliceFlag, returns
+// nil if not found
+func (c *Context) Uint64Slice(name string) []uint64 {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return lookupUint64Slice(name, fs)
+	}
+	return nil
+}
+
+func lookupUint64Slice(name string, set *flag.FlagSet) []uint64 {
+	f := set.Lookup(name)
+	if f != nil {
+		if s, ok := f.Value.(*Uint64Slice); ok {
+			return s.Value()
+		}
+	}
+	return nil
+}
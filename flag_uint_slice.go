@@ -0,0 +1,277 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// UintSlice wraps []uint to satisfy flag.Value
+type UintSlice struct {
+	value      *[]uint
+	hasBeenSet bool
+	sliceSeparator
+}
+
+// NewUintSlice makes an *UintSlice with default values
+func NewUintSlice(defaults ...uint) *UintSlice {
+	return newUintSlice(defaults, nil)
+}
+
+func newUintSlice(value []uint, p *[]uint) *UintSlice {
+	slice := new(UintSlice)
+	if p == nil {
+		p = &[]uint{}
+	}
+	slice.value = p
+	*slice.value = value
+	return slice
+}
+
+// Set parses the value into an unsigned integer and appends it to the list of values
+func (i *UintSlice) Set(value string) error {
+	if i.value == nil {
+		i.value = &[]uint{}
+	}
+	if !i.hasBeenSet {
+		*i.value = []uint{}
+		i.hasBeenSet = true
+	}
+
+	if strings.HasPrefix(value, slPfx) {
+		// Deserializing assumes overwrite
+		_ = json.Unmarshal([]byte(strings.Replace(value, slPfx, "", 1)), &(*i.value))
+		i.hasBeenSet = true
+		return nil
+	}
+
+	tmp, err := uintSliceConv(i.splitList(value))
+	if err != nil {
+		return err
+	}
+	*i.value = append(*i.value, tmp...)
+
+	return nil
+}
+
+func uintSliceConv(ss []string) ([]uint, error) {
+	out := make([]uint, len(ss))
+	for i, d := range ss {
+		n, err := strconv.ParseUint(strings.TrimSpace(d), 10, 0)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = uint(n)
+	}
+	return out, nil
+}
+
+// String returns a readable representation of this value (for usage defaults)
+func (i *UintSlice) String() string {
+	if i.value == nil {
+		return "[]"
+	}
+	out := make([]string, len(*i.value))
+	for i, d := range *i.value {
+		out[i] = fmt.Sprintf("%d", d)
+	}
+	return "[" + strings.Join(out, ",") + "]"
+}
+
+// Serialize allows UintSlice to fulfill Serializer
+func (i *UintSlice) Serialize() string {
+	jsonBytes, _ := json.Marshal(*i.value)
+	return fmt.Sprintf("%s%s", slPfx, string(jsonBytes))
+}
+
+// Value returns the slice of unsigned ints set by this flag
+func (i *UintSlice) Value() []uint {
+	if i.value == nil {
+		i.value = &[]uint{}
+	}
+	return *i.value
+}
+
+// Get returns the slice of unsigned ints set by this flag
+func (i *UintSlice) Get() interface{} {
+	return *i
+}
+
+// UintSliceFlag is a flag with type *UintSlice
+type UintSliceFlag struct {
+	Name         string
+	Aliases      []string
+	Usage        string
+	EnvVars      []string
+	FilePath     string
+	Required     bool
+	Hidden       bool
+	Sensitive    bool
+	Separator    string
+	DisableSplit bool
+	EnvSeparator string
+	Value        *UintSlice
+	DefaultText  string
+	HasBeenSet   bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *UintSliceFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *UintSliceFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *UintSliceFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *UintSliceFlag) IsRequired() bool {
+	return f.Required
+}
+
+// IsSensitive returns whether or not the flag value must be kept out of
+// help, docs and errors
+func (f *UintSliceFlag) IsSensitive() bool {
+	return f.Sensitive
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *UintSliceFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *UintSliceFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *UintSliceFlag) GetValue() string {
+	if f.Value != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *UintSliceFlag) Apply(set *flag.FlagSet) error {
+	sep, envSep := sliceSeparators(f.Separator, f.DisableSplit, f.EnvSeparator)
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		f.Value = &UintSlice{}
+		f.Value.sliceSeparator = envSep
+
+		if err := f.Value.Set(val); err != nil {
+			return fmt.Errorf("could not parse %q as uint slice value for flag %s: %s", errorValue(f, val), f.Name, redactError(f, err, append([]string{val}, envSep.split(val)...)...))
+		}
+
+		f.HasBeenSet = true
+	}
+
+	if f.Value == nil {
+		f.Value = &UintSlice{}
+	}
+	f.Value.sliceSeparator = sep
+	for _, name := range f.Names() {
+		set.Var(f.Value, name, f.Usage)
+	}
+
+	return nil
+}
+
+func (a *App) uintSliceVar(p *[]uint, name, alias string, value []uint, usage, env string) {
+	if a.Flags == nil {
+		a.Flags = make([]Flag, 0)
+	}
+	flag := &UintSliceFlag{
+		Name:  name,
+		Usage: usage,
+		Value: newUintSlice(value, p),
+	}
+	if alias != "" {
+		flag.Aliases = []string{alias}
+	}
+	if env != "" {
+		flag.EnvVars = []string{env}
+	}
+	a.Flags = append(a.Flags, flag)
+}
+
+// UintSliceVar defines a []uint flag with specified name, default value, usage string and env string.
+// The argument p points to a []uint variable in which to store the value of the flag.
+func (a *App) UintSliceVar(p *[]uint, name string, value []uint, usage, env string) {
+	a.uintSliceVar(p, name, "", value, usage, env)
+}
+
+// UintSliceVarP is like UintSliceVar, but accepts a shorthand letter that can be used after a single dash.
+func (a *App) UintSliceVarP(p *[]uint, name, alias string, value []uint, usage, env string) {
+	a.uintSliceVar(p, name, alias, value, usage, env)
+}
+
+// UintSliceVar defines a []uint flag with specified name, default value, usage string and env string.
+// The argument p points to a []uint variable in which to store the value of the flag.
+func UintSliceVar(p *[]uint, name string, value []uint, usage, env string) {
+	CommandLine.UintSliceVar(p, name, value, usage, env)
+}
+
+// UintSliceVarP is like UintSliceVar, but accepts a shorthand letter that can be used after a single dash.
+func UintSliceVarP(p *[]uint, name, alias string, value []uint, usage, env string) {
+	CommandLine.UintSliceVarP(p, name, alias, value, usage, env)
+}
+
+// UintSlice defines a []uint flag with specified name, default value, usage string and env string.
+// The return value is the address of a []uint variable that stores the value of the flag.
+func (a *App) UintSlice(name string, value []uint, usage, env string) *[]uint {
+	p := new([]uint)
+	a.UintSliceVar(p, name, value, usage, env)
+	return p
+}
+
+// UintSliceP is like UintSlice, but accepts a shorthand letter that can be used after a single dash.
+func (a *App) UintSliceP(name, alias string, value []uint, usage, env string) *[]uint {
+	p := new([]uint)
+	a.UintSliceVarP(p, name, alias, value, usage, env)
+	return p
+}
+
+// UintSlice looks up the value of a local UintSliceFlag, returns
+// nil if not found
+func (c *Context) UintSlice(name string) []uint {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return lookupUintSlice(name, fs)
+	}
+	return nil
+}
+
+func lookupUintSlice(name string, set *flag.FlagSet) []uint {
+	f := set.Lookup(name)
+	if f != nil {
+		if s, ok := f.Value.(*UintSlice); ok {
+			return s.Value()
+		}
+	}
+	return nil
+}
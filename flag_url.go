@@ -0,0 +1,205 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// urlPassword matches the passwords in the userinfo of the URLs of a string
+var urlPassword = regexp.MustCompile(`(://[^/?#@:]*):[^/?#@]*@`)
+
+// redactURLs replaces the passwords in the URLs of s with xxxxx, like
+// url.URL.Redacted, even when s is not a valid URL or holds several URLs
+func redactURLs(s string) string {
+	return urlPassword.ReplaceAllString(s, "$1:xxxxx@")
+}
+
+// parseURL parses an absolute URL. When allowedSchemes is not empty, the
+// scheme of the URL must be one of them, compared case insensitively.
+func parseURL(s string, allowedSchemes []string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil {
+		if ue, ok := err.(*url.Error); ok {
+			err = ue.Err
+		}
+		return nil, fmt.Errorf("invalid URL %q: %v", redactURLs(s), err)
+	}
+	if u.Scheme == "" {
+		return nil, fmt.Errorf("invalid URL %q: missing scheme", redactURLs(s))
+	}
+
+	if len(allowedSchemes) == 0 {
+		return u, nil
+	}
+	for _, scheme := range allowedSchemes {
+		if strings.EqualFold(scheme, u.Scheme) {
+			return u, nil
+		}
+	}
+	return nil, fmt.Errorf("URL scheme %q not allowed, expected one of %s", u.Scheme, strings.Join(allowedSchemes, ", "))
+}
+
+// urlValue is a flag.Value parsing URLs, copying the URL to dest when set
+type urlValue struct {
+	value          *url.URL
+	dest           *url.URL
+	allowedSchemes []string
+}
+
+func newURLValue(val *url.URL, p *url.URL, allowedSchemes []string) *urlValue {
+	v := &urlValue{value: val, dest: p, allowedSchemes: allowedSchemes}
+	if val != nil && p != nil {
+		*p = *val
+	}
+	return v
+}
+
+func (u *urlValue) Set(s string) error {
+	parsed, err := parseURL(s, u.allowedSchemes)
+	if err != nil {
+		return err
+	}
+	u.value = parsed
+	if u.dest != nil {
+		*u.dest = *parsed
+	}
+	return nil
+}
+
+func (u *urlValue) Get() interface{} { return u.value }
+
+func (u *urlValue) String() string {
+	if u.value == nil {
+		return ""
+	}
+	return u.value.String()
+}
+
+// URLFlag is a flag with type *url.URL. The URL must be absolute and, when
+// AllowedSchemes is not empty, use one of these schemes.
+type URLFlag struct {
+	Name           string
+	Aliases        []string
+	Usage          string
+	EnvVars        []string
+	FilePath       string
+	Required       bool
+	Hidden         bool
+	Sensitive      bool
+	Value          string
+	AllowedSchemes []string
+	DefaultText    string
+	Destination    *url.URL
+	HasBeenSet     bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *URLFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *URLFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *URLFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *URLFlag) IsRequired() bool {
+	return f.Required
+}
+
+// IsSensitive returns whether or not the flag value must be kept out of
+// help, docs and errors
+func (f *URLFlag) IsSensitive() bool {
+	return f.Sensitive
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *URLFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *URLFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all. The password of the URL is
+// redacted.
+func (f *URLFlag) GetValue() string {
+	return redactURLs(f.Value)
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *URLFlag) Apply(set *flag.FlagSet) error {
+	var value *url.URL
+	if f.Value != "" {
+		u, err := parseURL(f.Value, f.AllowedSchemes)
+		if err != nil {
+			return fmt.Errorf("invalid default value for flag %s: %s", f.Name, redactError(f, err, redactURLs(f.Value)))
+		}
+		value = u
+	}
+
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		if val != "" {
+			u, err := parseURL(val, f.AllowedSchemes)
+			if err != nil {
+				return fmt.Errorf("could not parse %q as URL value for flag %s: %s", errorValue(f, redactURLs(val)), f.Name, redactError(f, err, redactURLs(val)))
+			}
+
+			f.Value = u.String()
+			value = u
+			f.HasBeenSet = true
+		}
+	}
+
+	for _, name := range f.Names() {
+		set.Var(newURLValue(value, f.Destination, f.AllowedSchemes), name, f.Usage)
+	}
+
+	return nil
+}
+
+// URL looks up the value of a local URLFlag, returns
+// nil if not found
+func (c *Context) URL(name string) *url.URL {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return lookupURL(name, fs)
+	}
+	return nil
+}
+
+func lookupURL(name string, set *flag.FlagSet) *url.URL {
+	f := set.Lookup(name)
+	if f != nil {
+		if v, ok := f.Value.(*urlValue); ok {
+			return v.value
+		}
+	}
+	return nil
+}
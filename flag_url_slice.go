@@ -0,0 +1,214 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// URLSlice wraps a []*url.URL to satisfy flag.Value
+type URLSlice struct {
+	value          []*url.URL
+	hasBeenSet     bool
+	allowedSchemes []string
+	sliceSeparator
+}
+
+// NewURLSlice creates a *URLSlice with default values
+func NewURLSlice(defaults ...*url.URL) *URLSlice {
+	return &URLSlice{value: append([]*url.URL{}, defaults...)}
+}
+
+// Set parses the comma separated URLs of value and appends them to the list
+// of values. URLs containing commas must be given as separate values, e.g.
+// from a config file.
+func (s *URLSlice) Set(value string) error {
+	if !s.hasBeenSet {
+		s.value = []*url.URL{}
+		s.hasBeenSet = true
+	}
+
+	values, overwrite := s.splitValue(value)
+	if overwrite {
+		s.value = []*url.URL{}
+	}
+	for _, v := range values {
+		u, err := parseURL(v, s.allowedSchemes)
+		if err != nil {
+			return err
+		}
+		s.value = append(s.value, u)
+	}
+
+	return nil
+}
+
+// String returns a readable representation of this value (for usage defaults)
+func (s *URLSlice) String() string {
+	return "[" + strings.Join(s.strings(), ",") + "]"
+}
+
+// Serialize allows URLSlice to fulfill Serializer
+func (s *URLSlice) Serialize() string {
+	return serializeSlice(s.strings())
+}
+
+// Value returns the slice of URLs set by this flag
+func (s *URLSlice) Value() []*url.URL {
+	return s.value
+}
+
+// Get returns the slice of URLs set by this flag
+func (s *URLSlice) Get() interface{} {
+	return s.value
+}
+
+// redactedStrings returns the URLs with their passwords redacted
+func (s *URLSlice) redactedStrings() []string {
+	if s == nil {
+		return nil
+	}
+	out := make([]string, len(s.value))
+	for i, u := range s.value {
+		out[i] = u.Redacted()
+	}
+	return out
+}
+
+func (s *URLSlice) strings() []string {
+	if s == nil {
+		return nil
+	}
+	out := make([]string, len(s.value))
+	for i, u := range s.value {
+		out[i] = u.String()
+	}
+	return out
+}
+
+// URLSliceFlag is a flag with type *URLSlice. The URLs must be absolute and,
+// when AllowedSchemes is not empty, use one of these schemes.
+type URLSliceFlag struct {
+	Name           string
+	Aliases        []string
+	Usage          string
+	EnvVars        []string
+	FilePath       string
+	Required       bool
+	Hidden         bool
+	Sensitive      bool
+	Separator      string
+	DisableSplit   bool
+	EnvSeparator   string
+	Value          *URLSlice
+	AllowedSchemes []string
+	DefaultText    string
+	HasBeenSet     bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *URLSliceFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *URLSliceFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *URLSliceFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *URLSliceFlag) IsRequired() bool {
+	return f.Required
+}
+
+// IsSensitive returns whether or not the flag value must be kept out of
+// help, docs and errors
+func (f *URLSliceFlag) IsSensitive() bool {
+	return f.Sensitive
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *URLSliceFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *URLSliceFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all. The passwords of the URLs are
+// redacted.
+func (f *URLSliceFlag) GetValue() string {
+	if f.Value != nil {
+		return "[" + strings.Join(f.Value.redactedStrings(), ",") + "]"
+	}
+	return ""
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *URLSliceFlag) Apply(set *flag.FlagSet) error {
+	sep, envSep := sliceSeparators(f.Separator, f.DisableSplit, f.EnvSeparator)
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		f.Value = &URLSlice{allowedSchemes: f.AllowedSchemes}
+		f.Value.sliceSeparator = envSep
+
+		if err := f.Value.Set(val); err != nil {
+			return fmt.Errorf("could not parse %q as URL value for flag %s: %s", errorValue(f, redactURLs(val)), f.Name, redactError(f, err, append([]string{redactURLs(val)}, envSep.split(redactURLs(val))...)...))
+		}
+
+		f.HasBeenSet = true
+	}
+
+	if f.Value == nil {
+		f.Value = &URLSlice{}
+	}
+	f.Value.sliceSeparator = sep
+	f.Value.allowedSchemes = f.AllowedSchemes
+	for _, name := range f.Names() {
+		set.Var(f.Value, name, f.Usage)
+	}
+
+	return nil
+}
+
+// URLSlice looks up the value of a local URLSliceFlag, returns
+// nil if not found
+func (c *Context) URLSlice(name string) []*url.URL {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return lookupURLSlice(name, fs)
+	}
+	return nil
+}
+
+func lookupURLSlice(name string, set *flag.FlagSet) []*url.URL {
+	f := set.Lookup(name)
+	if f != nil {
+		if s, ok := f.Value.(*URLSlice); ok {
+			return s.Value()
+		}
+	}
+	return nil
+}
@@ -32,7 +32,9 @@ var helpCommand = &Command{
 	Action: func(c *Context) error {
 		args := c.Args()
 		if args.Present() {
-			_ = ShowCommandHelp(c, args.First())
+			if err := ShowCommandHelp(c, args.First()); err != nil {
+				return err
+			}
 			os.Exit(0)
 			return nil
 		}
@@ -51,7 +53,9 @@ var helpSubcommand = &Command{
 	Action: func(c *Context) error {
 		args := c.Args()
 		if args.Present() {
-			_ = ShowCommandHelp(c, args.First())
+			if err := ShowCommandHelp(c, args.First()); err != nil {
+				return err
+			}
 			os.Exit(0)
 			return nil
 		}
@@ -159,7 +163,7 @@ func printFlagSuggestions(lastArg string, flags []Flag, writer io.Writer) {
 		if bflag, ok := flag.(*BoolFlag); ok && bflag.Hidden {
 			continue
 		}
-		for _, name := range flag.Names() {
+		for _, name := range append(flag.Names(), flagNegatedNames(flag)...) {
 			name = strings.TrimSpace(name)
 			// this will get total count utf8 letters in flag name
 			count := utf8.RuneCountInString(name)
@@ -185,9 +189,11 @@ func DefaultCompleteWithFlags(cmd *Command) func(c *Context) {
 		if len(os.Args) > 2 {
 			lastArg := os.Args[len(os.Args)-2]
 			if strings.HasPrefix(lastArg, "-") {
-				printFlagSuggestions(lastArg, c.App.Flags, c.App.Writer)
 				if cmd != nil {
-					printFlagSuggestions(lastArg, cmd.Flags, c.App.Writer)
+					printFlagSuggestions(lastArg, c.App.Flags, c.App.Writer)
+					printFlagSuggestions(lastArg, cmd.allFlags(), c.App.Writer)
+				} else {
+					printFlagSuggestions(lastArg, c.App.allFlags(), c.App.Writer)
 				}
 				return
 			}
@@ -214,21 +220,30 @@ func ShowCommandHelp(ctx *Context, command string) error {
 		return nil
 	}
 
-	for _, c := range ctx.App.Commands {
-		if c.HasName(command) {
-			templ := c.CustomHelpTemplate
-			if templ == "" {
-				templ = CommandHelpTemplate
-			}
+	if c, _ := ctx.App.findCommand(command); c != nil {
+		templ := c.CustomHelpTemplate
+		if templ == "" {
+			templ = CommandHelpTemplate
+		}
 
-			HelpPrinter(ctx.App.Writer, templ, c)
+		// help may be shown before the command runs, e.g. `app help cmd`
+		c.inheritedFlags = inheritedFlags(ctx, c.localFlags())
 
-			return nil
-		}
+		HelpPrinter(ctx.App.Writer, templ, c)
+
+		return nil
+	}
+
+	if !ctx.App.DisableSuggestions {
+		ctx.suggestion = SuggestCommand(ctx.App.Commands, command)
 	}
 
 	if ctx.App.CommandNotFound == nil {
-		return Exit(fmt.Sprintf("No help topic for '%v'", command), 3)
+		msg := fmt.Sprintf("No help topic for '%v'", command)
+		if ctx.suggestion != "" {
+			msg += ". " + ctx.suggestion
+		}
+		return Exit(msg, 3)
 	}
 
 	ctx.App.CommandNotFound(ctx, command)
@@ -174,6 +174,65 @@ func Test_helpCommand_InHelpOutput(t *testing.T) {
 	}
 }
 
+func Test_ShowCommandHelp_SuggestsCommand(t *testing.T) {
+	app := &App{
+		Commands: []*Command{
+			{Name: "status", Aliases: []string{"st"}},
+			{Name: "secret", Hidden: true},
+		},
+	}
+
+	set := flag.NewFlagSet("test", 0)
+	c := NewContext(app, set, nil)
+
+	err := ShowCommandHelp(c, "staus")
+	expect(t, err.Error(), `No help topic for 'staus'. Did you mean "status"?`)
+
+	err = ShowCommandHelp(c, "secre")
+	expect(t, err.Error(), "No help topic for 'secre'")
+
+	app.DisableSuggestions = true
+	err = ShowCommandHelp(c, "staus")
+	expect(t, err.Error(), "No help topic for 'staus'")
+}
+
+func TestSuggestFlag(t *testing.T) {
+	flags := []Flag{
+		&BoolFlag{Name: "verbose", Aliases: []string{"V"}},
+		&BoolFlag{Name: "color", Negatable: true},
+		&StringFlag{Name: "token", Hidden: true},
+		HelpFlag,
+	}
+
+	cases := []struct {
+		provided string
+		hideHelp bool
+		expected string
+	}{
+		{provided: "-verbos", expected: `Did you mean "--verbose"?`},
+		{provided: "--vrebose", expected: `Did you mean "--verbose"?`},
+		{provided: "--no-colr", expected: `Did you mean "--no-color"?`},
+		{provided: "--hepl", expected: `Did you mean "--help"?`},
+		{provided: "--hepl", hideHelp: true},
+		{provided: "--toke"},
+		{provided: "-x"},
+		{provided: "--debug"},
+	}
+
+	for _, c := range cases {
+		expect(t, SuggestFlag(flags, c.provided, c.hideHelp), c.expected)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	expect(t, editDistance("", "abc"), 3)
+	expect(t, editDistance("status", "status"), 0)
+	expect(t, editDistance("status", "staus"), 1)
+	expect(t, editDistance("status", "sattus"), 1)
+	expect(t, editDistance("kitten", "sitting"), 3)
+	expect(t, editDistance("ca", "abc"), 3)
+}
+
 func Test_helpSubcommand_Action_ErrorIfNoTopic(t *testing.T) {
 	app := &App{}
 
@@ -15,13 +15,20 @@
 package cli
 
 import (
+	"errors"
 	"flag"
+	"fmt"
+	"strconv"
 	"strings"
 )
 
 type iterativeParser interface {
+	definedFlags() []Flag
 	newFlagSet() (*flag.FlagSet, error)
 	useShortOptionHandling() bool
+	allowInterspersedFlags() bool
+	allowAbbreviations() bool
+	hasCommand(name string) bool
 }
 
 // To enable short-option handling (e.g., "-it" vs "-i -t") we have to
@@ -30,7 +37,25 @@ type iterativeParser interface {
 // combined short options from common arguments that should be left untouched.
 // Pass `shellComplete` to continue parsing options on failure during shell
 // completion when, the user-supplied options may be incomplete.
-func parseIter(set *flag.FlagSet, ip iterativeParser, args []string, shellComplete bool) error {
+func parseIter(set *flag.FlagSet, ip iterativeParser, args []string, shellComplete bool) (err error) {
+	defer func() {
+		err = redactParseError(err, ip.definedFlags())
+	}()
+
+	if ip.allowInterspersedFlags() {
+		args = reorderArgs(set, args, ip)
+	}
+
+	if ip.allowAbbreviations() {
+		expanded, err := expandAbbreviations(set, args, ip)
+		if err != nil && !shellComplete {
+			return err
+		}
+		if err == nil {
+			args = expanded
+		}
+	}
+
 	for {
 		err := set.Parse(args)
 		if !ip.useShortOptionHandling() || err == nil {
@@ -50,13 +75,14 @@ func parseIter(set *flag.FlagSet, ip iterativeParser, args []string, shellComple
 		argsWereSplit := false
 		for i, arg := range args {
 			// skip args that are not part of the error message
-			if name := strings.TrimPrefix(arg, "-"); name != trimmed {
+			name := strings.TrimPrefix(arg, "-")
+			if name != trimmed && !strings.HasPrefix(name, trimmed+"=") {
 				continue
 			}
 
 			// if we can't split, the error was accurate
 			shortOpts := splitShortOptions(set, arg)
-			if len(shortOpts) == 1 {
+			if shortOpts[0] == arg {
 				return err
 			}
 
@@ -81,23 +107,215 @@ func parseIter(set *flag.FlagSet, ip iterativeParser, args []string, shellComple
 	}
 }
 
-func splitShortOptions(set *flag.FlagSet, arg string) []string {
-	shortFlagsExist := func(s string) bool {
-		for _, c := range s[1:] {
-			if f := set.Lookup(string(c)); f == nil {
-				return false
+// redactParseError hides the value from the "invalid value" errors of the
+// flag package when it was given to a sensitive flag, and the passwords of
+// the URLs given to any flag.
+func redactParseError(err error, flags []Flag) error {
+	if err == nil {
+		return nil
+	}
+
+	const prefix = "invalid value "
+	msg := err.Error()
+	if !strings.HasPrefix(msg, prefix) {
+		return err
+	}
+	for _, f := range flags {
+		for _, name := range f.Names() {
+			idx := strings.Index(msg, " for flag -"+name+": ")
+			if idx < 0 {
+				continue
 			}
+			val, uerr := strconv.Unquote(msg[len(prefix):idx])
+			if uerr != nil {
+				return err
+			}
+			val = redactURLs(val)
+			if !isSensitive(f) {
+				return errors.New(prefix + strconv.Quote(val) + msg[idx:])
+			}
+			// slice flags report the failing element on its own
+			vals := []string{val}
+			for _, part := range strings.Split(val, ",") {
+				vals = append(vals, strings.TrimSpace(part))
+			}
+			cause := redactError(f, errors.New(msg[idx:]), vals...)
+			return errors.New(prefix + strconv.Quote(redactedValue) + cause.Error())
 		}
-		return true
 	}
+	return err
+}
 
-	if !isSplittable(arg) || !shortFlagsExist(arg) {
+// reorderArgs moves the flags found among the positional arguments in front
+// of them, since flag.FlagSet.Parse stops at the first positional argument.
+// Everything after "--" is kept as positional arguments, and a subcommand
+// name given as the first positional argument ends the flags of this level.
+func reorderArgs(set *flag.FlagSet, args []string, ip iterativeParser) []string {
+	flags := make([]string, 0, len(args))
+	var positionals []string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			positionals = append(positionals, args[i+1:]...)
+			i = len(args)
+		case len(arg) < 2 || arg[0] != '-':
+			if len(positionals) == 0 && ip.hasCommand(arg) {
+				return append(flags, args[i:]...)
+			}
+			positionals = append(positionals, arg)
+		default:
+			flags = append(flags, arg)
+			// abbreviations are expanded later on, but an abbreviated flag
+			// may take the next argument as value
+			name := arg
+			if ip.allowAbbreviations() {
+				if full, err := expandAbbreviation(set, arg, ip); err == nil {
+					name = full
+				}
+			}
+			if i+1 < len(args) && flagTakesValue(set, name, ip.useShortOptionHandling()) {
+				i++
+				flags = append(flags, args[i])
+			}
+		}
+	}
+
+	if len(positionals) == 0 {
+		return flags
+	}
+	return append(append(flags, "--"), positionals...)
+}
+
+// expandAbbreviations replaces the unique prefixes of long flag names given
+// with two leading dashes by the full names. The flags of this level end at
+// "--" or at the first positional argument.
+func expandAbbreviations(set *flag.FlagSet, args []string, ip iterativeParser) ([]string, error) {
+	expanded := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" || len(arg) < 2 || arg[0] != '-' {
+			return append(expanded, args[i:]...), nil
+		}
+
+		arg, err := expandAbbreviation(set, arg, ip)
+		if err != nil {
+			return nil, err
+		}
+
+		expanded = append(expanded, arg)
+		if i+1 < len(args) && flagTakesValue(set, arg, ip.useShortOptionHandling()) {
+			i++
+			expanded = append(expanded, args[i])
+		}
+	}
+	return expanded, nil
+}
+
+// expandAbbreviation replaces the unique prefix of a long flag name given
+// with two leading dashes by the full name
+func expandAbbreviation(set *flag.FlagSet, arg string, ip iterativeParser) (string, error) {
+	if !strings.HasPrefix(arg, "--") {
+		return arg, nil
+	}
+
+	name, value := arg[2:], ""
+	if idx := strings.Index(name, "="); idx >= 0 {
+		name, value = name[:idx], name[idx:]
+	}
+	if name == "" || set.Lookup(name) != nil {
+		return arg, nil
+	}
+	full, err := abbreviatedFlag(ip.definedFlags(), name)
+	if err != nil || full == "" {
+		return arg, err
+	}
+	return "--" + full + value, nil
+}
+
+// abbreviatedFlag returns the long name of the visible flag abbreviated by
+// prefix, or an empty string if there is none
+func abbreviatedFlag(flags []Flag, prefix string) (string, error) {
+	var candidates []string
+	for _, f := range flags {
+		if isHidden(f) {
+			continue
+		}
+		// the negated names of a flag are a flag on their own
+		for _, names := range [][]string{f.Names(), flagNegatedNames(f)} {
+			for _, name := range names {
+				if len(name) > 1 && strings.HasPrefix(name, prefix) {
+					candidates = append(candidates, name)
+					break
+				}
+			}
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return "", nil
+	case 1:
+		return candidates[0], nil
+	}
+	return "", fmt.Errorf("ambiguous flag: --%s could be --%s", prefix, strings.Join(candidates, ", --"))
+}
+
+// flagTakesValue reports whether arg is a flag expecting its value in the
+// next argument
+func flagTakesValue(set *flag.FlagSet, arg string, shortOptionHandling bool) bool {
+	name := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+	if strings.Contains(name, "=") {
+		return false
+	}
+
+	if f := set.Lookup(name); f != nil {
+		return !isBoolFlag(f)
+	}
+
+	// only the last option of combined short options may take a value
+	if shortOptionHandling {
+		if shortOpts := splitShortOptions(set, arg); shortOpts[0] != arg {
+			return flagTakesValue(set, shortOpts[len(shortOpts)-1], false)
+		}
+	}
+
+	return false
+}
+
+func isBoolFlag(f *flag.Flag) bool {
+	bf, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && bf.IsBoolFlag()
+}
+
+// splitShortOptions splits combined short options the way getopt does: each
+// character is a flag until one that takes a value, which takes the rest of
+// the argument ("-ofile", "-o=file") or else the next argument. The argument
+// is returned as is when it can't be split.
+func splitShortOptions(set *flag.FlagSet, arg string) []string {
+	if !isSplittable(arg) {
 		return []string{arg}
 	}
 
-	separated := make([]string, 0, len(arg)-1)
-	for _, flagChar := range arg[1:] {
-		separated = append(separated, "-"+string(flagChar))
+	opts := arg[1:]
+	separated := make([]string, 0, len(opts))
+	for i, flagChar := range opts {
+		f := set.Lookup(string(flagChar))
+		if f == nil {
+			return []string{arg}
+		}
+
+		opt := "-" + string(flagChar)
+		if isBoolFlag(f) {
+			separated = append(separated, opt)
+			continue
+		}
+
+		if value := opts[i+len(string(flagChar)):]; value != "" {
+			opt += "=" + strings.TrimPrefix(value, "=")
+		}
+		return append(separated, opt)
 	}
 
 	return separated
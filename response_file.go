@@ -0,0 +1,163 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// expandResponseFiles replaces the @file arguments by the arguments read
+// from the files. Arguments starting with @@ stand for themselves without
+// the first @, and arguments after "--" are never expanded.
+func expandResponseFiles(args []string) ([]string, error) {
+	expanded, _, err := expandResponseArgs(args, "", nil)
+	return expanded, err
+}
+
+// expandResponseArgs expands args, reading relative response files from
+// dir. It reports whether "--" was met, which ends the expansion of the
+// including files too.
+func expandResponseArgs(args []string, dir string, including []string) ([]string, bool, error) {
+	expanded := make([]string, 0, len(args))
+	for i, arg := range args {
+		switch {
+		case arg == "--":
+			return append(expanded, args[i:]...), true, nil
+		case strings.HasPrefix(arg, "@@"):
+			expanded = append(expanded, arg[1:])
+		case len(arg) > 1 && arg[0] == '@':
+			fileArgs, done, err := readResponseFile(arg[1:], dir, including)
+			if err != nil {
+				return nil, false, err
+			}
+			expanded = append(expanded, fileArgs...)
+			if done {
+				return append(expanded, args[i+1:]...), true, nil
+			}
+		default:
+			expanded = append(expanded, arg)
+		}
+	}
+	return expanded, false, nil
+}
+
+func readResponseFile(name, dir string, including []string) ([]string, bool, error) {
+	path := name
+	if !filepath.IsAbs(path) && dir != "" {
+		path = filepath.Join(dir, path)
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to read response file %s: %v", name, err)
+	}
+
+	for _, p := range including {
+		if p == abs {
+			return nil, false, fmt.Errorf("response file %s includes itself", name)
+		}
+	}
+
+	data, err := ioutil.ReadFile(abs)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to read response file %s: %v", name, err)
+	}
+
+	args, err := splitResponseFile(string(data))
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to parse response file %s: %v", name, err)
+	}
+
+	return expandResponseArgs(args, filepath.Dir(abs), append(including, abs))
+}
+
+// splitResponseFile splits the content of a response file into arguments
+// the way a POSIX shell splits words: whitespace separates arguments, single
+// quotes keep everything literally, double quotes and backslashes escape
+// characters, and an unquoted # starting a word comments out the rest of the
+// line.
+func splitResponseFile(s string) ([]string, error) {
+	var (
+		args  []string
+		word  strings.Builder
+		quote rune
+		inArg bool
+	)
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch quote {
+		case '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+			continue
+		case '"':
+			switch {
+			case r == '"':
+				quote = 0
+			case r == '\\' && i+1 < len(runes) && strings.ContainsRune("\"\\$`\n", runes[i+1]):
+				i++
+				if runes[i] != '\n' {
+					word.WriteRune(runes[i])
+				}
+			default:
+				word.WriteRune(r)
+			}
+			continue
+		}
+
+		switch {
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == '\\':
+			if i+1 < len(runes) {
+				i++
+				if runes[i] != '\n' {
+					word.WriteRune(runes[i])
+					inArg = true
+				}
+			}
+		case r == '#' && !inArg:
+			for i+1 < len(runes) && runes[i+1] != '\n' {
+				i++
+			}
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inArg {
+				args = append(args, word.String())
+				word.Reset()
+				inArg = false
+			}
+		default:
+			word.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inArg {
+		args = append(args, word.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SuggestDidYouMeanTemplate is the text shown with the closest name to an
+// unknown command or flag
+var SuggestDidYouMeanTemplate = "Did you mean %q?"
+
+// SuggestFlag returns a suggestion for the visible flag closest to the
+// unknown flag provided, or an empty string if none is close enough
+func SuggestFlag(flags []Flag, provided string, hideHelp bool) string {
+	provided = strings.TrimLeft(provided, "-")
+
+	var names []string
+	for _, f := range flags {
+		if isHidden(f) || (hideHelp && f == HelpFlag) {
+			continue
+		}
+		names = append(names, f.Names()...)
+		names = append(names, flagNegatedNames(f)...)
+	}
+
+	name := closestName(names, provided)
+	if name == "" {
+		return ""
+	}
+	return fmt.Sprintf(SuggestDidYouMeanTemplate, prefixFor(name)+name)
+}
+
+// SuggestCommand returns a suggestion for the visible command closest to
+// the unknown command provided, or an empty string if none is close enough
+func SuggestCommand(commands []*Command, provided string) string {
+	var names []string
+	for _, c := range commands {
+		if !c.Hidden {
+			names = append(names, c.Names()...)
+		}
+	}
+
+	name := closestName(names, provided)
+	if name == "" {
+		return ""
+	}
+	return fmt.Sprintf(SuggestDidYouMeanTemplate, name)
+}
+
+// suggestFlagFromError returns a suggestion for the unknown flag reported
+// by err, or an empty string
+func (a *App) suggestFlagFromError(err error, flags []Flag) string {
+	if a.DisableSuggestions || err == nil {
+		return ""
+	}
+
+	provided := strings.TrimPrefix(err.Error(), "flag provided but not defined: ")
+	if provided == err.Error() {
+		return ""
+	}
+	return SuggestFlag(flags, provided, a.HideHelp)
+}
+
+// closestName returns the name with the smallest edit distance to provided,
+// as long as it is small compared to the length of provided
+func closestName(names []string, provided string) string {
+	n := len([]rune(provided))
+	maxDistance := n / 3
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+
+	closest, closestDistance := "", maxDistance+1
+	for _, name := range names {
+		if name == provided {
+			continue
+		}
+		if d := editDistance(name, provided); d < closestDistance && d < n {
+			closest, closestDistance = name, d
+		}
+	}
+	return closest
+}
+
+// editDistance returns the optimal string alignment distance of a and b: the
+// number of insertions, deletions, substitutions and transpositions of
+// adjacent characters turning a into b
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = minInt(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(s)][len(t)]
+}
+
+func minInt(n int, ns ...int) int {
+	for _, v := range ns {
+		if v < n {
+			n = v
+		}
+	}
+	return n
+}
@@ -70,6 +70,9 @@ DESCRIPTION:
 
 OPTIONS:
    {{range .VisibleFlags}}{{.}}
+   {{end}}{{end}}{{if .VisibleInheritedFlags}}
+INHERITED OPTIONS:
+   {{range .VisibleInheritedFlags}}{{.}}
    {{end}}{{end}}
 `
 
@@ -89,6 +92,9 @@ COMMANDS:{{range .VisibleCategories}}{{if .Name}}
 
 OPTIONS:
    {{range .VisibleFlags}}{{.}}
+   {{end}}{{end}}{{if .VisibleInheritedFlags}}
+INHERITED OPTIONS:
+   {{range .VisibleInheritedFlags}}{{.}}
    {{end}}{{end}}
 `
 